@@ -30,6 +30,10 @@ var (
 // For example, if a FieldName "Foo" was added to a map with key "bar",
 // the prefix would be {Name: "Foo", Key: "bar"}, and the default change logger
 // would print "Foo[bar] added with value 'value'".
+//
+// Elements of slices and maps nested in other slices and maps are identified
+// by prefixes with an empty name, e.g. {Name: "Foo", Key: "bar"} followed by
+// {Key: "2"} is printed as "Foo[bar][2]".
 type Prefix struct {
 	Name FieldName
 	Key  string
@@ -115,6 +119,7 @@ func NewDefaultFormatter(options ...func(*DefaultFormatter)) *DefaultFormatter {
 }
 
 // WithPrintNameAndKey sets the function used for printing a prefix as name with key.
+// Elements of nested slices and maps are printed with an empty name.
 func WithPrintNameAndKey(printNameAndKey func(name, key string) string) func(*DefaultFormatter) {
 	return func(f *DefaultFormatter) {
 		f.printNameAndKey = printNameAndKey
@@ -133,44 +138,45 @@ func printNameAndKey(name, key string) string {
 func (f *DefaultFormatter) joinPrefixes(prefixes []Prefix) string {
 	result := ""
 	for i := range prefixes {
-		if prefixes[i].Name == FieldNameEmpty {
-			continue
-		}
+		result = f.appendPath(result, prefixes[i].Name, prefixes[i].Key)
+	}
 
-		nameAndKey := f.printNameAndKey(string(prefixes[i].Name), prefixes[i].Key)
-		if result == "" {
-			result = nameAndKey
-		} else {
-			result = fmt.Sprintf("%s %s", result, nameAndKey)
+	return result
+}
+
+// appendPath appends a name and key to the path of a change.
+// Prefixes without a name, used for elements of nested slices and maps,
+// append their key to the previous element, e.g. "Tags[env][2]".
+func (f *DefaultFormatter) appendPath(path string, name FieldName, key string) string {
+	if name == FieldNameEmpty {
+		if key == "" {
+			return path
 		}
+		return path + f.printNameAndKey("", key)
 	}
 
-	if result == "" {
-		return result
+	nameAndKey := f.printNameAndKey(string(name), key)
+	if path == "" {
+		return nameAndKey
 	}
-	return result + " "
+	return fmt.Sprintf("%s %s", path, nameAndKey)
 }
 
 // Format formats a change to a human readable string.
 func (f *DefaultFormatter) Format(c *Change) string {
-	prefix := f.joinPrefixes(c.Prefix)
-
-	fieldName := c.FieldName
-	if c.Key != "" {
-		fieldName = f.printNameAndKey(fieldName, c.Key)
-	}
+	fieldName := f.appendPath(f.joinPrefixes(c.Prefix), FieldName(c.FieldName), c.Key)
 
 	switch c.Operation {
 	case OperationAdded:
-		return fmt.Sprintf("%s%s %s with value '%s'", prefix, fieldName, c.Operation, c.NewValue)
+		return fmt.Sprintf("%s %s with value '%s'", fieldName, c.Operation, c.NewValue)
 	case OperationRemoved:
-		return fmt.Sprintf("%s%s %s, value was '%s'", prefix, fieldName, c.Operation, c.OldValue)
+		return fmt.Sprintf("%s %s, value was '%s'", fieldName, c.Operation, c.OldValue)
 	case OperationUpdated:
-		return fmt.Sprintf("%s%s %s from '%s' to '%s'", prefix, fieldName, c.Operation, c.OldValue, c.NewValue)
+		return fmt.Sprintf("%s %s from '%s' to '%s'", fieldName, c.Operation, c.OldValue, c.NewValue)
 	case OperationSet:
-		return fmt.Sprintf("%s%s %s to '%s'", prefix, fieldName, c.Operation, c.NewValue)
+		return fmt.Sprintf("%s %s to '%s'", fieldName, c.Operation, c.NewValue)
 	case OperationCleared:
-		return fmt.Sprintf("%s%s %s, value was '%s'", prefix, fieldName, c.Operation, c.OldValue)
+		return fmt.Sprintf("%s %s, value was '%s'", fieldName, c.Operation, c.OldValue)
 	}
 	return ""
 }
@@ -0,0 +1,153 @@
+package changes
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+)
+
+// The functions below log the changes made to fields like the generated
+// mutators, for mutators of your own, like the runtime mutators of package
+// mutate. They are given the name of the field in changes, empty for slices
+// and maps held by elements of slices and maps, and format values with %+v.
+
+// LogSet logs that a field was set from current to value, whose zero values
+// are reported by currentZero and valueZero. The change is a set if the field
+// held its zero value, a clear if value is the zero value, and an update
+// otherwise.
+func LogSet(logger Logger, name string, current, value interface{}, currentZero, valueZero bool) {
+	logger.Append(Change{
+		FieldName: name,
+		Operation: setOperation(currentZero, valueZero),
+		OldValue:  fmt.Sprintf("%+v", current),
+		NewValue:  fmt.Sprintf("%+v", value),
+	})
+}
+
+// LogSetBytes logs that a byte slice field was set from current to value,
+// like LogSet with empty slices as zero values. Changes report the bytes
+// encoded in base64.
+func LogSetBytes(logger Logger, name string, current, value []byte) {
+	logger.Append(Change{
+		FieldName: name,
+		Operation: setOperation(len(current) == 0, len(value) == 0),
+		OldValue:  base64.StdEncoding.EncodeToString(current),
+		NewValue:  base64.StdEncoding.EncodeToString(value),
+	})
+}
+
+// LogSetContainer logs that a slice or map field was set from current to
+// value, which is a clear if value is empty, and a set otherwise.
+func LogSetContainer(logger Logger, name string, current, value interface{}, valueEmpty bool) {
+	operation := OperationSet
+	if valueEmpty {
+		operation = OperationCleared
+	}
+
+	logger.Append(Change{
+		FieldName: name,
+		Operation: operation,
+		OldValue:  fmt.Sprintf("%+v", current),
+		NewValue:  fmt.Sprintf("%+v", value),
+	})
+}
+
+// LogSetPointer logs that a pointer field was set from current to value,
+// which is a clear if value is nil, and a set otherwise. Changes report the
+// values pointed to, or the pointers themselves if they implement
+// fmt.Stringer.
+func LogSetPointer(logger Logger, name string, current, value interface{}) {
+	operation := OperationSet
+	if reflect.ValueOf(value).IsNil() {
+		operation = OperationCleared
+	}
+
+	logger.Append(Change{
+		FieldName: name,
+		Operation: operation,
+		OldValue:  formatPointer(current),
+		NewValue:  formatPointer(value),
+	})
+}
+
+// LogSetObject logs that a field holding a struct was set from current to
+// the struct value points to.
+func LogSetObject(logger Logger, name string, current, value interface{}) {
+	logger.Append(Change{
+		FieldName: name,
+		Operation: OperationSet,
+		OldValue:  fmt.Sprintf("%+v", current),
+		NewValue:  fmt.Sprintf("%+v", value),
+	})
+}
+
+// LogAppend logs that values, a slice, were appended to a field. A single
+// value is reported as is, several values as a slice.
+func LogAppend(logger Logger, name string, values interface{}) {
+	if slice := reflect.ValueOf(values); slice.Len() == 1 {
+		values = slice.Index(0).Interface()
+	}
+
+	logger.Append(Change{
+		FieldName: name,
+		Operation: OperationAdded,
+		NewValue:  fmt.Sprintf("%+v", values),
+	})
+}
+
+// LogRemoveIndex logs that value, an element of a slice field, was removed.
+func LogRemoveIndex(logger Logger, name string, value interface{}) {
+	logger.Append(Change{
+		FieldName: name,
+		Operation: OperationRemoved,
+		OldValue:  fmt.Sprintf("%+v", value),
+	})
+}
+
+// LogInsert logs that value was inserted with key into a map field.
+func LogInsert(logger Logger, name string, key, value interface{}) {
+	logger.Append(Change{
+		FieldName: name,
+		Operation: OperationAdded,
+		Key:       IntoKey(key),
+		NewValue:  fmt.Sprintf("%+v", value),
+	})
+}
+
+// LogRemoveKey logs that value, the element with key of a map field, was
+// removed.
+func LogRemoveKey(logger Logger, name string, key, value interface{}) {
+	logger.Append(Change{
+		FieldName: name,
+		Operation: OperationRemoved,
+		Key:       IntoKey(key),
+		OldValue:  fmt.Sprintf("%+v", value),
+	})
+}
+
+func setOperation(currentZero, valueZero bool) Operation {
+	switch {
+	case currentZero:
+		return OperationSet
+	case valueZero:
+		return OperationCleared
+	default:
+		return OperationUpdated
+	}
+}
+
+// formatPointer formats pointer, printing the value it points to unless it
+// implements fmt.Stringer or is nil.
+func formatPointer(pointer interface{}) string {
+	// the + flag is not passed to stringers, which may also be formatters
+	// printing signs with it, like *big.Int
+	if _, isStringer := pointer.(fmt.Stringer); isStringer {
+		return fmt.Sprintf("%v", pointer)
+	}
+
+	if value := reflect.ValueOf(pointer); !value.IsNil() {
+		return fmt.Sprintf("%+v", value.Elem().Interface())
+	}
+
+	return fmt.Sprintf("%+v", pointer)
+}
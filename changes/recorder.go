@@ -0,0 +1,51 @@
+package changes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Call is a call to a method of a fake mutator.
+type Call struct {
+	// Method is the called method, preceded by the calls returning its
+	// mutator from the root mutator, e.g. "EmployeesAt(1).SetName".
+	Method string
+	Args   []interface{}
+}
+
+// String formats the call like Go code, e.g. "EmployeesAt(1).SetName(John)".
+func (c Call) String() string {
+	args := make([]string, len(c.Args))
+	for i := range c.Args {
+		args[i] = fmt.Sprintf("%+v", c.Args[i])
+	}
+
+	return fmt.Sprintf("%s(%s)", c.Method, strings.Join(args, ", "))
+}
+
+// Recorder records the calls to the methods of fake mutators which would
+// mutate their objects. Its zero value is ready to use.
+type Recorder struct {
+	calls []Call
+}
+
+// Record records a call to method with args.
+func (r *Recorder) Record(method string, args ...interface{}) {
+	r.calls = append(r.calls, Call{
+		Method: method,
+		Args:   args,
+	})
+}
+
+// Calls returns the recorded calls, in the order they were made.
+func (r *Recorder) Calls() []Call {
+	return r.calls
+}
+
+// ToString converts the recorded calls to a slice of strings.
+func (r *Recorder) ToString() (result []string) {
+	for i := range r.calls {
+		result = append(result, r.calls[i].String())
+	}
+	return
+}
@@ -0,0 +1,160 @@
+package changes
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+)
+
+// The functions below implement the methods of the generated mutators, which
+// call them with the logger of the mutator and the name of the field in
+// changes, empty for slices and maps held by elements of slices and maps.
+// They report whether the field changed.
+
+// IsZero reports whether field holds the zero value of its type.
+func IsZero[T any](field *T) bool {
+	return reflect.ValueOf(field).Elem().IsZero()
+}
+
+// Set sets field to value unless equal is set, which reports whether they are
+// equal, see LogSet.
+func Set[T any](logger Logger, name string, field *T, value T, equal bool) bool {
+	if equal {
+		return false
+	}
+
+	LogSet(logger, name, *field, value, IsZero(field), IsZero(&value))
+	*field = value
+
+	return true
+}
+
+// SetEnum sets field to value, rejecting values which are not keys of names,
+// which maps the declared constants of an enum to their names. Changes report
+// the names of the constants.
+func SetEnum[T comparable](logger Logger, name string, field *T, value T, names map[T]string) bool {
+	newName, valid := names[value]
+	if !valid {
+		return false
+	}
+
+	if *field == value {
+		return false
+	}
+
+	oldName, known := names[*field]
+	if !known {
+		oldName = fmt.Sprintf("%+v", *field)
+	}
+
+	LogSet(logger, name, oldName, newName, IsZero(field), IsZero(&value))
+	*field = value
+
+	return true
+}
+
+// SetBytes sets field to value, see LogSetBytes.
+func SetBytes[S ~[]byte](logger Logger, name string, field *S, value S) bool {
+	if bytes.Equal(*field, value) {
+		return false
+	}
+
+	LogSetBytes(logger, name, *field, value)
+	*field = value
+
+	return true
+}
+
+// SetSlice sets field to value, unless both are empty.
+func SetSlice[S ~[]E, E any](logger Logger, name string, field *S, value S) bool {
+	if len(value) == 0 && len(*field) == 0 {
+		return false
+	}
+
+	LogSetContainer(logger, name, *field, value, len(value) == 0)
+	*field = value
+
+	return true
+}
+
+// SetMap sets field to value, unless both are empty.
+func SetMap[M ~map[K]V, K comparable, V any](logger Logger, name string, field *M, value M) bool {
+	if len(value) == 0 && len(*field) == 0 {
+		return false
+	}
+
+	LogSetContainer(logger, name, *field, value, len(value) == 0)
+	*field = value
+
+	return true
+}
+
+// SetPointer sets field to value unless both are nil or equal is set, which
+// reports whether they are equal, see LogSetPointer.
+func SetPointer[T any](logger Logger, name string, field **T, value *T, equal bool) bool {
+	if value == nil && *field == nil {
+		return false
+	}
+
+	if equal {
+		return false
+	}
+
+	LogSetPointer(logger, name, *field, value)
+	*field = value
+
+	return true
+}
+
+// SetObject sets field, holding a struct, to the struct value points to. The
+// change is always reported.
+func SetObject[T any](logger Logger, name string, field *T, value *T) bool {
+	LogSetObject(logger, name, *field, value)
+	*field = *value
+
+	return true
+}
+
+// Append appends values to field, see LogAppend.
+func Append[S ~[]E, E any](logger Logger, name string, field *S, values ...E) {
+	LogAppend(logger, name, values)
+	*field = append(*field, values...)
+}
+
+// RemoveIndex removes the element of field at index.
+func RemoveIndex[S ~[]E, E any](logger Logger, name string, field *S, index int) {
+	LogRemoveIndex(logger, name, (*field)[index])
+	*field = append((*field)[:index], (*field)[index+1:]...)
+}
+
+// Insert inserts value into field with key, unless equal reports that it
+// holds an equal value with key. field is initialized if nil.
+func Insert[M ~map[K]V, K comparable, V any](logger Logger, name string, field *M, key K, value V, equal func(current, value V) bool) bool {
+	current, exists := (*field)[key]
+	if exists && equal(current, value) {
+		return false
+	}
+
+	LogInsert(logger, name, key, value)
+
+	if *field == nil {
+		*field = make(M)
+	}
+
+	(*field)[key] = value
+
+	return true
+}
+
+// RemoveKey removes the element of field with key, if any.
+func RemoveKey[M ~map[K]V, K comparable, V any](logger Logger, name string, field *M, key K) bool {
+	current, exists := (*field)[key]
+	if !exists {
+		return false
+	}
+
+	LogRemoveKey(logger, name, key, current)
+	delete(*field, key)
+
+	return true
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines around changes in unified diffs.
+const diffContext = 3
+
+// edit is a line of a diff, kept (' '), deleted ('-') or inserted ('+'),
+// ending with its newline unless it is the last line of a file without one.
+type edit struct {
+	kind byte
+	line string
+}
+
+// unifiedDiff returns the unified diff from old to new, or an empty string if
+// they are equal.
+func unifiedDiff(oldName, newName string, old, new []byte) string {
+	if string(old) == string(new) {
+		return ""
+	}
+
+	edits := diffLines(splitLines(string(old)), splitLines(string(new)))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+
+	// line numbers in old and new before each edit
+	oldLines := make([]int, len(edits)+1)
+	newLines := make([]int, len(edits)+1)
+	for i, e := range edits {
+		oldLines[i+1], newLines[i+1] = oldLines[i], newLines[i]
+		if e.kind != '+' {
+			oldLines[i+1]++
+		}
+		if e.kind != '-' {
+			newLines[i+1]++
+		}
+	}
+
+	for start := 0; start < len(edits); {
+		// find the next change, and the last change of its hunk
+		first := start
+		for first < len(edits) && edits[first].kind == ' ' {
+			first++
+		}
+		if first == len(edits) {
+			break
+		}
+
+		last := first
+		for i := first + 1; i < len(edits) && i <= last+2*diffContext; i++ {
+			if edits[i].kind != ' ' {
+				last = i
+			}
+		}
+
+		from := first - diffContext
+		if from < start {
+			from = start
+		}
+		to := last + diffContext + 1
+		if to > len(edits) {
+			to = len(edits)
+		}
+
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n",
+			hunkRange(oldLines[from], oldLines[to]-oldLines[from]),
+			hunkRange(newLines[from], newLines[to]-newLines[from]),
+		)
+		for _, e := range edits[from:to] {
+			fmt.Fprintf(&b, "%c%s", e.kind, e.line)
+			if !strings.HasSuffix(e.line, "\n") {
+				b.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+
+		start = to
+	}
+
+	return b.String()
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// splitLines splits s after each newline, so that a last line without one
+// differs from the same line with one, like in diff -u.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns the shortest edit script from a to b, following the
+// linear space variant of Myers' "An O(ND) Difference Algorithm and Its
+// Variations", so that memory does not grow with the number of changes.
+// Deletions are listed before the insertions they are next to, like in diff -u.
+func diffLines(a, b []string) []edit {
+	d := differ{a: a, b: b}
+	d.compare(0, len(a), 0, len(b))
+
+	for start := 0; start < len(d.edits); start++ {
+		end := start
+		for end < len(d.edits) && d.edits[end].kind != ' ' {
+			end++
+		}
+
+		changes := d.edits[start:end]
+		sort.SliceStable(changes, func(i, j int) bool {
+			return changes[i].kind == '-' && changes[j].kind == '+'
+		})
+
+		start = end
+	}
+
+	return d.edits
+}
+
+// differ holds the edits of diffLines, appended in order by compare.
+type differ struct {
+	a, b  []string
+	edits []edit
+}
+
+// compare appends the edits from a[aLo:aHi] to b[bLo:bHi], dividing them at
+// the middle snake of their shortest edit script.
+func (d *differ) compare(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		d.edits = append(d.edits, edit{' ', d.a[aLo]})
+		aLo++
+		bLo++
+	}
+
+	suffix := 0
+	for aLo < aHi-suffix && bLo < bHi-suffix && d.a[aHi-suffix-1] == d.b[bHi-suffix-1] {
+		suffix++
+	}
+	aHi -= suffix
+	bHi -= suffix
+
+	switch {
+	case aLo == aHi:
+		for _, line := range d.b[bLo:bHi] {
+			d.edits = append(d.edits, edit{'+', line})
+		}
+	case bLo == bHi:
+		for _, line := range d.a[aLo:aHi] {
+			d.edits = append(d.edits, edit{'-', line})
+		}
+	default:
+		x, y, u, v := d.middleSnake(aLo, aHi, bLo, bHi)
+		d.compare(aLo, x, bLo, y)
+		for _, line := range d.a[x:u] {
+			d.edits = append(d.edits, edit{' ', line})
+		}
+		d.compare(u, aHi, v, bHi)
+	}
+
+	for _, line := range d.a[aHi : aHi+suffix] {
+		d.edits = append(d.edits, edit{' ', line})
+	}
+}
+
+// middleSnake returns the start (x, y) and end (u, v) of the snake in the
+// middle of the shortest edit script from a[aLo:aHi] to b[bLo:bHi], found by
+// searching from both ends until their paths overlap.
+func (d *differ) middleSnake(aLo, aHi, bLo, bHi int) (x, y, u, v int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	odd := delta%2 != 0
+	maxD := (n + m + 1) / 2
+
+	// forward holds the furthest x reached from the start on each diagonal
+	// k = x - y, and backward the smallest x reached from the end, both
+	// relative to aLo and bLo
+	offset := maxD + 1
+	forward := make([]int, 2*offset+1)
+	backward := make([]int, 2*offset+1)
+	backward[offset-1] = n
+
+	for step := 0; step <= maxD; step++ {
+		for k := -step; k <= step; k += 2 {
+			var x int
+			if k == -step || (k != step && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && d.a[aLo+x] == d.b[bLo+y] {
+				x++
+				y++
+			}
+
+			forward[offset+k] = x
+
+			// backward diagonal of k, after step-1 steps
+			if back := k - delta; odd && back >= -(step-1) && back <= step-1 && x >= backward[offset+back] {
+				return aLo + startX, bLo + startY, aLo + x, bLo + y
+			}
+		}
+
+		// backward diagonals are relative to delta, the diagonal of the end
+		for back := -step; back <= step; back += 2 {
+			k := back + delta
+
+			var x int
+			if back == step || (back != -step && backward[offset+back-1] < backward[offset+back+1]) {
+				x = backward[offset+back-1]
+			} else {
+				x = backward[offset+back+1] - 1
+			}
+
+			y := x - k
+			endX, endY := x, y
+			for x > 0 && y > 0 && d.a[aLo+x-1] == d.b[bLo+y-1] {
+				x--
+				y--
+			}
+
+			backward[offset+back] = x
+
+			if !odd && k >= -step && k <= step && x <= forward[offset+k] {
+				return aLo + x, bLo + y, aLo + endX, bLo + endY
+			}
+		}
+	}
+
+	panic("diff: no middle snake")
+}
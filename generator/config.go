@@ -0,0 +1,339 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConfigFile is the name of the configuration file read from the package
+// directory, unless another file is given with Options.Config.
+const ConfigFile = "gomutate.json"
+
+// config holds the generation settings of a package, e.g.
+//
+//	{
+//		"types": ["Acme", "Supplier"],
+//		"output": "mutations.go",
+//		"exclude": ["Acme.Secret"],
+//		"fields": {"Acme.ID": "immutable"}
+//	}
+//
+// Flags override the settings they correspond to. Settings apply to all the
+// types of the package, unless replaced by the settings of a type, see
+// typeConfig.
+type config struct {
+	// Types are the root types, like -type. In package pattern mode, they are
+	// generated along with the annotated types.
+	Types typeList `json:"types,omitempty"`
+	// Output is the output file relative to the package directory, like -w.
+	Output string `json:"output,omitempty"`
+	// OutputPackage is the name of the package of the output file when it is
+	// not the package of the types, like -output-package.
+	OutputPackage string `json:"outputPackage,omitempty"`
+	// Unexported is set to mutate the unexported fields and struct types of
+	// the package, like -unexported.
+	Unexported bool `json:"unexported,omitempty"`
+	// Interfaces is set to generate an interface and a recording fake for
+	// each mutator, like -interfaces.
+	Interfaces bool `json:"interfaces,omitempty"`
+	// Tags are the comma-separated build tags applied when loading the
+	// package, like -tags. They are ignored in package pattern mode, where
+	// packages are loaded before their configuration is read.
+	Tags string `json:"tags,omitempty"`
+	// Exclude lists the fields for which no code is generated, e.g.
+	// "Acme.Secret", like the "-" option of the mutate struct tag.
+	Exclude []string `json:"exclude,omitempty"`
+	// Fields holds the options of fields, e.g. "Acme.ID": "immutable",
+	// written like the mutate struct tag, whose options they replace.
+	// Fields of types of other packages are qualified, e.g. "billing.Account.IBAN".
+	Fields map[string]string `json:"fields,omitempty"`
+	// Format is set to false to write the generated code without formatting it.
+	Format *bool `json:"format,omitempty"`
+	// Naming replaces the default names of the generated methods and types.
+	Naming naming `json:"naming"`
+}
+
+// typeConfig holds the settings of a root type, given when types are listed
+// by an object, e.g.
+//
+//	"types": {
+//		"Acme": {"exclude": ["Secret"], "naming": {"set": "Update{Field}"}},
+//		"Supplier": {}
+//	}
+//
+// They replace the settings of the package for the fields and methods of the
+// mutator of the type only, the struct types it reaches have mutators of
+// their own, which may be shared with other types.
+type typeConfig struct {
+	// Exclude lists the fields of the type for which no code is generated.
+	Exclude []string `json:"exclude,omitempty"`
+	// Fields holds the options of the fields of the type, by field name.
+	Fields map[string]string `json:"fields,omitempty"`
+	// Naming replaces the names of the methods of the mutator of the type,
+	// while the prefixes of mutator types and constructors are those of the
+	// package.
+	Naming naming `json:"naming"`
+}
+
+// typeList lists the root types, decoded from either a list of names or an
+// object holding the settings of each type by name, in declaration order.
+type typeList struct {
+	names    []string
+	settings map[string]typeConfig
+}
+
+func (l *typeList) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		return decoder.Decode(&l.names)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+
+	l.settings = make(map[string]typeConfig)
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		name := token.(string)
+		if _, exists := l.settings[name]; exists {
+			return fmt.Errorf("type %s is given twice", name)
+		}
+
+		var settings typeConfig
+		if err := decoder.Decode(&settings); err != nil {
+			return fmt.Errorf("type %s: %w", name, err)
+		}
+
+		l.names = append(l.names, name)
+		l.settings[name] = settings
+	}
+
+	_, err := decoder.Token()
+
+	return err
+}
+
+// fieldPlaceholder is replaced with the field name in method name patterns.
+const fieldPlaceholder = "{Field}"
+
+// naming holds the patterns of the names of the methods generated for each
+// field, in which {Field} is replaced with the field name, and the prefixes
+// of the names of the mutator types and their constructors. The methods of
+// the mutators of nested slices and maps are named with an empty field name,
+// e.g. At for {Field}At.
+type naming struct {
+	Set         string `json:"set,omitempty"`
+	At          string `json:"at,omitempty"`
+	ByPtr       string `json:"byPtr,omitempty"`
+	WithKey     string `json:"withKey,omitempty"`
+	Append      string `json:"append,omitempty"`
+	Insert      string `json:"insert,omitempty"`
+	Remove      string `json:"remove,omitempty"`
+	Mutator     string `json:"mutator,omitempty"`
+	Constructor string `json:"constructor,omitempty"`
+}
+
+var defaultNaming = naming{
+	Set:         "Set{Field}",
+	At:          "{Field}At",
+	ByPtr:       "{Field}ByPtr",
+	WithKey:     "{Field}WithKey",
+	Append:      "Append{Field}",
+	Insert:      "Insert{Field}",
+	Remove:      "Remove{Field}",
+	Mutator:     "Mutator",
+	Constructor: "NewMutator",
+}
+
+// withDefaults returns n with the default names of those not set.
+func (n naming) withDefaults() naming {
+	return n.or(defaultNaming)
+}
+
+// or returns n with the names of fallback for those not set.
+func (n naming) or(fallback naming) naming {
+	for _, name := range []struct {
+		value    *string
+		fallback string
+	}{
+		{&n.Set, fallback.Set},
+		{&n.At, fallback.At},
+		{&n.ByPtr, fallback.ByPtr},
+		{&n.WithKey, fallback.WithKey},
+		{&n.Append, fallback.Append},
+		{&n.Insert, fallback.Insert},
+		{&n.Remove, fallback.Remove},
+		{&n.Mutator, fallback.Mutator},
+		{&n.Constructor, fallback.Constructor},
+	} {
+		if *name.value == "" {
+			*name.value = name.fallback
+		}
+	}
+
+	return n
+}
+
+// validate reports patterns without the field name, or which do not make
+// identifiers, and prefixes which are not identifiers.
+func (n naming) validate() error {
+	for _, pattern := range []string{n.Set, n.At, n.ByPtr, n.WithKey, n.Append, n.Insert, n.Remove} {
+		if strings.Count(pattern, fieldPlaceholder) != 1 {
+			return fmt.Errorf("invalid method name %q, it must contain %s once", pattern, fieldPlaceholder)
+		}
+
+		if !token.IsIdentifier(strings.Replace(pattern, fieldPlaceholder, "", 1)) {
+			return fmt.Errorf("invalid method name %q, it must be an identifier without %s", pattern, fieldPlaceholder)
+		}
+	}
+
+	for _, prefix := range []string{n.Mutator, n.Constructor} {
+		if !token.IsIdentifier(prefix) {
+			return fmt.Errorf("invalid prefix %q, it must be an identifier", prefix)
+		}
+	}
+
+	return nil
+}
+
+// methods returns the names of the methods generated for field.
+func (n naming) methods(field string) methodNames {
+	name := func(pattern string) string {
+		return strings.Replace(pattern, fieldPlaceholder, field, 1)
+	}
+
+	return methodNames{
+		Set:     name(n.Set),
+		At:      name(n.At),
+		ByPtr:   name(n.ByPtr),
+		WithKey: name(n.WithKey),
+		Append:  name(n.Append),
+		Insert:  name(n.Insert),
+		Remove:  name(n.Remove),
+	}
+}
+
+// readConfig reads the configuration file given by filename, like -config,
+// or the one of the package directory dir, if any.
+func readConfig(dir, filename string) (config, error) {
+	var cfg config
+
+	optional := filename == ""
+	if optional {
+		filename = filepath.Join(dir, ConfigFile)
+	}
+
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) && optional {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("invalid configuration file %s: %w", filename, err)
+	}
+
+	if err := cfg.Naming.withDefaults().validate(); err != nil {
+		return cfg, fmt.Errorf("invalid configuration file %s: %w", filename, err)
+	}
+
+	for _, name := range cfg.Types.names {
+		if err := cfg.validateType(name); err != nil {
+			return cfg, fmt.Errorf("invalid configuration file %s: type %s: %w", filename, name, err)
+		}
+	}
+
+	return cfg, nil
+}
+
+// validateType reports invalid settings of the type with given name.
+func (c config) validateType(name string) error {
+	settings := c.Types.settings[name]
+
+	if strings.Contains(name, "[") && (len(settings.Exclude) != 0 || len(settings.Fields) != 0 || settings.Naming != naming{}) {
+		return errors.New("settings of generic types must be given to the generic type, and apply to its instantiations")
+	}
+
+	if settings.Naming.Mutator != "" || settings.Naming.Constructor != "" {
+		return errors.New("the mutator and constructor prefixes cannot be set per type")
+	}
+
+	return c.naming(name).validate()
+}
+
+// fieldTags returns the mutate struct tag options replacing those of fields,
+// by Type.Field, those of the settings of types replacing those of the package.
+func (c config) fieldTags() map[string]string {
+	tags := make(map[string]string, len(c.Fields)+len(c.Exclude))
+	for field, options := range c.Fields {
+		tags[field] = options
+	}
+
+	for _, field := range c.Exclude {
+		tags[field] = "-"
+	}
+
+	for name, settings := range c.Types.settings {
+		for field, options := range settings.Fields {
+			tags[name+"."+field] = options
+		}
+
+		for _, field := range settings.Exclude {
+			tags[name+"."+field] = "-"
+		}
+	}
+
+	return tags
+}
+
+// naming returns the naming of the type with given name, whose settings
+// replace the naming of the package.
+func (c config) naming(name string) naming {
+	return c.Types.settings[name].Naming.or(c.Naming).withDefaults()
+}
+
+// typeNaming returns the naming of the types with their own, by name.
+func (c config) typeNaming() map[string]naming {
+	names := make(map[string]naming)
+	for name, settings := range c.Types.settings {
+		if settings.Naming != (naming{}) {
+			names[name] = c.naming(name)
+		}
+	}
+
+	return names
+}
+
+func (c config) format() bool {
+	return c.Format == nil || *c.Format
+}
+
+// output returns the output file relative to the working directory, given the
+// package directory dir, or an empty string if not set.
+func (c config) output(dir string) string {
+	if c.Output == "" || filepath.IsAbs(c.Output) {
+		return c.Output
+	}
+
+	return filepath.Join(dir, c.Output)
+}
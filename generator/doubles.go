@@ -0,0 +1,222 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"strings"
+)
+
+// interfaceSuffix and fakePrefix name the interface and the recording fake of
+// a mutator, e.g. AcmeMutator and FakeAcmeMutator for MutatorAcme.
+const (
+	interfaceSuffix = "Mutator"
+	fakePrefix      = "Fake"
+)
+
+// addDoubles returns body, the generated code of package packageName, with an
+// interface and a recording fake for each mutator. The methods of mutators
+// returning other mutators return their interfaces instead, so that mutators
+// implement their interfaces and fakes can return fakes.
+func addDoubles(packageName string, body []byte, naming naming, templates templateSet) ([]byte, error) {
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "", append([]byte("package "+packageName+"\n"), body...), parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parsing generated code: %w", err)
+	}
+
+	methods := make(map[string][]*ast.FuncDecl)
+	for _, decl := range file.Decls {
+		if name := methodName(decl); name != "" {
+			recv, _, _ := strings.Cut(name, ".")
+			methods[recv] = append(methods[recv], decl.(*ast.FuncDecl))
+		}
+	}
+
+	// mutators are the types with methods named with the mutator prefix
+	var mutators []*ast.TypeSpec
+	interfaces := make(map[string]string)
+	for _, decl := range file.Decls {
+		genDecl, isGenDecl := decl.(*ast.GenDecl)
+		if !isGenDecl || genDecl.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			name := typeSpec.Name.Name
+			if len(methods[name]) == 0 || !strings.HasPrefix(name, naming.Mutator) {
+				continue
+			}
+
+			mutators = append(mutators, typeSpec)
+			interfaces[name] = strings.TrimPrefix(name, naming.Mutator) + interfaceSuffix
+		}
+	}
+
+	var steps []templateStep
+
+	for _, mutator := range mutators {
+		name := mutator.Name.Name
+
+		data := doubleData{
+			Mutator:   name,
+			Interface: interfaces[name],
+			Fake:      fakePrefix + interfaces[name],
+		}
+
+		if params := mutator.TypeParams; params != nil {
+			args := make([]string, 0, params.NumFields())
+			for _, field := range params.List {
+				for _, ident := range field.Names {
+					args = append(args, ident.Name)
+				}
+			}
+
+			data.TypeParams = "[" + formatFields(fset, params) + "]"
+			data.TypeArgs = "[" + strings.Join(args, ", ") + "]"
+		}
+
+		for _, method := range methods[name] {
+			if !method.Name.IsExported() {
+				continue
+			}
+
+			data.Methods = append(data.Methods, doubleMethod(fset, method, interfaces))
+		}
+
+		steps = append(steps,
+			templateStep{template: &mutatorInterfaceTemplate, data: data},
+			templateStep{template: &mutatorFakeTemplate, data: data},
+		)
+	}
+
+	var source bytes.Buffer
+	if err := format.Node(&source, fset, file); err != nil {
+		return nil, err
+	}
+
+	if err := executeSteps(&source, steps, templates); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimPrefix(source.Bytes(), []byte("package "+packageName+"\n")), nil
+}
+
+// doubleMethod describes method in the interface and the fake of its mutator,
+// making it return the interface of the mutator it returns, if any.
+func doubleMethod(fset *token.FileSet, method *ast.FuncDecl, interfaces map[string]string) doubleMethodData {
+	data := doubleMethodData{
+		Name: method.Name.Name,
+	}
+
+	var args []string
+	for _, field := range method.Type.Params.List {
+		for _, ident := range field.Names {
+			args = append(args, ident.Name)
+		}
+	}
+
+	results := method.Type.Results
+	if results != nil && len(results.List) == 1 && len(results.List[0].Names) == 0 {
+		result := results.List[0]
+
+		if star, isStar := result.Type.(*ast.StarExpr); isStar {
+			if ident := embeddedIdent(star.X); ident != nil && interfaces[ident.Name] != "" {
+				ident.Name = interfaces[ident.Name]
+				result.Type = star.X
+
+				// the fake returns a fake of the returned mutator, recording
+				// the calls to its methods with the path leading to it
+				verbs := strings.TrimSuffix(strings.Repeat("%+v, ", len(args)), ", ")
+				path := fmt.Sprintf("%q", data.Name+"().")
+				if len(args) != 0 {
+					path = fmt.Sprintf("fmt.Sprintf(%q, %s)", data.Name+"("+verbs+").", strings.Join(args, ", "))
+				}
+
+				data.Doc = "returns a fake recording the calls to the methods of the mutator"
+				data.Return = fmt.Sprintf("&%s%s{\n\t\trecorder: m.recorder,\n\t\tpath: m.path + %s,\n\t}", fakePrefix, formatNode(fset, star.X), path)
+			}
+		}
+	}
+
+	data.Params = formatFields(fset, method.Type.Params)
+	data.Args = args
+
+	// only the calls which may mutate the object are recorded, like setters
+	// reporting changes or methods without results
+	if results == nil || len(results.List) == 0 {
+		data.Record = true
+		data.Doc = "records the call"
+	} else {
+		data.Record = data.Return == "" && formatFields(fset, results) == "bool"
+
+		data.Results = formatFields(fset, results)
+		if len(results.List) > 1 || len(results.List[0].Names) != 0 {
+			data.Results = "(" + data.Results + ")"
+		}
+
+		if data.Return == "" {
+			data.Doc, data.Return = fakeResult(fset, results)
+		}
+
+		if data.Record {
+			data.Doc = "records the call, reporting a change"
+		}
+	}
+
+	return data
+}
+
+// fakeResult returns the values returned by fakes for results, along with
+// their description: true for setters reporting changes, the recorded calls
+// for FormatChanges, and zero values otherwise.
+func fakeResult(fset *token.FileSet, results *ast.FieldList) (string, string) {
+	if len(results.List) == 1 && len(results.List[0].Names) == 0 {
+		switch formatNode(fset, results.List[0].Type) {
+		case "bool":
+			return "", "true"
+		case "[]string":
+			return "returns the recorded calls", "m.recorder.ToString()"
+		}
+	}
+
+	var values []string
+	for _, field := range results.List {
+		for i := 0; i < len(field.Names) || i == 0; i++ {
+			values = append(values, "*new("+formatNode(fset, field.Type)+")")
+		}
+	}
+
+	return "returns zero values", strings.Join(values, ", ")
+}
+
+// formatFields formats a list of parameters, results or type parameters.
+func formatFields(fset *token.FileSet, fields *ast.FieldList) string {
+	list := make([]string, 0, len(fields.List))
+	for _, field := range fields.List {
+		names := make([]string, len(field.Names))
+		for i, ident := range field.Names {
+			names[i] = ident.Name
+		}
+
+		if len(names) == 0 {
+			list = append(list, formatNode(fset, field.Type))
+		} else {
+			list = append(list, strings.Join(names, ", ")+" "+formatNode(fset, field.Type))
+		}
+	}
+
+	return strings.Join(list, ", ")
+}
+
+func formatNode(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, fset, node)
+	return buf.String()
+}
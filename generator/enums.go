@@ -0,0 +1,124 @@
+package generator
+
+import (
+	"go/types"
+	"sort"
+)
+
+// handleEnum generates the names of the constants declared with type t,
+// returning the name of the generated map, or an empty string if t is not an
+// enum. Enums are named basic types of the package of the roots, or of another
+// package of the module, with at least one constant declared in their package.
+// Only the exported enums and constants of other packages than the generated
+// one are used. Fields with the noenum option are not handled as enums.
+func (h *handler) handleEnum(t types.Type) string {
+	consts := h.enumConstants(t)
+	if len(consts) == 0 {
+		return ""
+	}
+
+	ident, _ := h.typeIdent(t)
+	names := "enumNames" + ident
+	if !h.claimType(names, h.typeName(t)) {
+		return names
+	}
+
+	data := enumData{
+		Names:    names,
+		TypeName: h.typeName(t),
+	}
+
+	for _, c := range consts {
+		data.Constants = append(data.Constants, enumConstantData{
+			Name:        h.objectName(c),
+			DisplayName: c.Name(),
+		})
+	}
+
+	h.steps = append(h.steps, templateStep{
+		template: &enumNamesTemplate,
+		data:     data,
+	})
+
+	return names
+}
+
+func (h *handler) handleEnumField(
+	owner mutatorData,
+	field fieldInfo,
+	fieldType types.Type,
+) []templateStep {
+	enum := h.handleEnum(fieldType)
+
+	if !field.options.Settable() {
+		return nil
+	}
+
+	h.addSetter(owner, field, false)
+
+	return []templateStep{
+		{
+			template: &mutateEnumTemplate,
+			data: mutateFunctionData{
+				TypeName:      owner.TypeName,
+				Mutator:       owner.Mutator,
+				FieldName:     field.Name(),
+				DisplayName:   field.displayName(),
+				FieldTypeName: h.typeName(fieldType),
+				EnumNames:     enum,
+				Immutable:     field.options.Immutable,
+			},
+		},
+	}
+}
+
+// enumConstants returns the constants declared with type t in declaration
+// order, skipping those sharing the value of a previous constant, or nil if t
+// is not an enum.
+func (h *handler) enumConstants(t types.Type) []*types.Const {
+	named, isNamed := t.(*types.Named)
+	if !isNamed || named.TypeParams().Len() > 0 {
+		return nil
+	}
+
+	if _, isBasic := named.Underlying().(*types.Basic); !isBasic {
+		return nil
+	}
+
+	// types of other packages, like time.Duration, are not enums
+	pkg := named.Obj().Pkg()
+	if pkg != h.pkg && !h.inModule(pkg) || pkg != h.output && !named.Obj().Exported() {
+		return nil
+	}
+
+	var consts []*types.Const
+
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		c, isConst := scope.Lookup(name).(*types.Const)
+		if !isConst || !types.Identical(c.Type(), named) {
+			continue
+		}
+
+		if pkg != h.output && !c.Exported() {
+			continue
+		}
+
+		consts = append(consts, c)
+	}
+
+	sort.Slice(consts, func(i, j int) bool {
+		return consts[i].Pos() < consts[j].Pos()
+	})
+
+	values := make(map[string]bool, len(consts))
+	unique := consts[:0]
+	for _, c := range consts {
+		if value := c.Val().ExactString(); !values[value] {
+			values[value] = true
+			unique = append(unique, c)
+		}
+	}
+
+	return unique
+}
@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/pdcalado/gomutate/internal/equality"
+)
+
+// equalExpr returns an expression reporting whether x and y, both of type t,
+// are equal, following the rules of package equality, like equality.Equal.
+// x and y must be addressable, since the methods may have pointer receivers.
+func equalExpr(t types.Type, x, y string) string {
+	if _, isPointer := t.Underlying().(*types.Pointer); isPointer {
+		if method := equalMethod(t); method != "" {
+			return fmt.Sprintf("(%[1]s == %[2]s || %[1]s != nil && %[2]s != nil && %[3]s)", x, y, fmt.Sprintf(method, x, y))
+		}
+	} else if !types.IsInterface(t) {
+		if method := equalMethod(t); method != "" {
+			return fmt.Sprintf(method, x, y)
+		}
+
+		if method := equalMethod(types.NewPointer(t)); method != "" {
+			return fmt.Sprintf(method, "(&"+x+")", "&"+y)
+		}
+	}
+
+	if types.Comparable(t) {
+		return fmt.Sprintf("%s == %s", x, y)
+	}
+
+	return fmt.Sprintf("reflect.DeepEqual(%s, %s)", x, y)
+}
+
+// equalMethod returns the format of the call to the first of the methods of
+// package equality declared by t, comparing it with another value of type t,
+// or an empty string if it has none of them.
+func equalMethod(t types.Type) string {
+	methods := types.NewMethodSet(t)
+
+	for _, candidate := range equality.Methods {
+		selection := methods.Lookup(nil, candidate.Name)
+		if selection == nil {
+			continue
+		}
+
+		signature := selection.Type().(*types.Signature)
+		if signature.Params().Len() != 1 || signature.Results().Len() != 1 || signature.Variadic() {
+			continue
+		}
+
+		if !types.Identical(signature.Params().At(0).Type(), t) {
+			continue
+		}
+
+		result, isBasic := signature.Results().At(0).Type().(*types.Basic)
+		if !isBasic || result.Name() != candidate.Result {
+			continue
+		}
+
+		return candidate.Format
+	}
+
+	return ""
+}
@@ -0,0 +1,171 @@
+package generator
+
+import (
+	"fmt"
+	"go/types"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FieldHandler generates the methods of the fields of the types it matches,
+// instead of the built-in ones, e.g. to support types of other modules like
+// uuid.UUID. Each field is handled by the first handler of the Options
+// matching its type.
+type FieldHandler struct {
+	// Match reports whether the handler generates the methods of fields of
+	// type t, as declared by their struct, see MatchType.
+	Match func(t types.Type) bool
+	// Template generates the methods of a field, with the data of the
+	// built-in templates of fields, e.g.
+	//
+	//	func (m *{{.Mutator}}) {{.Names.Set}}(value {{.FieldTypeName}}) bool {
+	//		return changes.Set(m.changes, "{{.DisplayName}}", &m.inner.{{.FieldName}}, value, {{.FieldEqual}})
+	//	}
+	//
+	// The options of the field are given by .Field, and are up to Template,
+	// e.g. {{if not .Field.Readonly}}.
+	Template string
+	// Setter is set if Template generates the setter {{.Names.Set}} taking a
+	// value of the field type, which is then promoted to the mutators of the
+	// structs embedding the struct of the field.
+	Setter bool
+	// Imports are the import paths of the packages referred to by Template
+	// and Helpers by their package name, e.g. null for gopkg.in/guregu/null.v4.
+	// Names of packages which are not dependencies of the package of the
+	// fields are assumed from their path, like goimports does.
+	Imports []string
+	// Helpers is the code generated once in the files generating the methods
+	// of matched fields, e.g. functions called by Template.
+	Helpers string
+}
+
+// MatchType returns a FieldHandler Match function matching the named type,
+// and its instantiations, declared in the package with given import path.
+func MatchType(pkgPath, name string) func(t types.Type) bool {
+	return func(t types.Type) bool {
+		named, isNamed := t.(*types.Named)
+		if !isNamed || named.Obj().Pkg() == nil {
+			return false
+		}
+
+		return named.Obj().Pkg().Path() == pkgPath && named.Obj().Name() == name
+	}
+}
+
+// fieldHandler returns the index of the first field handler matching t, or -1
+// if none does.
+func (h *handler) fieldHandler(t types.Type) int {
+	for i, fieldHandler := range h.fieldHandlers {
+		if fieldHandler.Match != nil && fieldHandler.Match(t) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// handleCustom generates the methods of field with the field handler at
+// index, and its imports and helpers the first time it is used.
+func (h *handler) handleCustom(
+	owner mutatorData,
+	field fieldInfo,
+	fieldType types.Type,
+	index int,
+) []templateStep {
+	fieldHandler := &h.fieldHandlers[index]
+
+	if !h.usedHandlers[index] {
+		h.usedHandlers[index] = true
+
+		for _, importPath := range fieldHandler.Imports {
+			name := packageName(h.pkg, importPath)
+
+			imported, exists := h.imports.names[importPath]
+			if !exists {
+				imported = h.imports.add(importPath, name)
+			}
+
+			if imported != name {
+				takenBy := h.imports.paths[name]
+				if takenBy == "" {
+					takenBy = "an identifier of the generated code"
+				}
+
+				h.fail(fmt.Errorf("import %s of field handler is named %s, which is taken by %s", importPath, name, takenBy))
+			}
+		}
+
+		h.steps = append(h.steps, templateStep{
+			template: &fieldHandler.Helpers,
+		})
+	}
+
+	if fieldHandler.Setter && field.options.Settable() {
+		h.addSetter(owner, field, false)
+	}
+
+	return []templateStep{
+		{
+			template: &fieldHandler.Template,
+			data: mutateFunctionData{
+				TypeName:      owner.TypeName,
+				Mutator:       owner.Mutator,
+				FieldName:     field.Name(),
+				DisplayName:   field.displayName(),
+				FieldTypeName: h.typeName(fieldType),
+				FieldEqual:    equalExpr(fieldType, "m.inner."+field.Name(), "value"),
+				Immutable:     field.options.Immutable,
+			},
+		},
+	}
+}
+
+// packageName returns the name of the package with given import path among
+// pkg and its dependencies, or, if not found, the name assumed from its path
+// like goimports does, e.g. null for gopkg.in/guregu/null.v4, or pgx for
+// github.com/jackc/pgx/v5.
+func packageName(pkg *types.Package, importPath string) string {
+	visited := make(map[*types.Package]bool)
+
+	var find func(pkg *types.Package) string
+	find = func(pkg *types.Package) string {
+		if visited[pkg] {
+			return ""
+		}
+		visited[pkg] = true
+
+		if pkg.Path() == importPath {
+			return pkg.Name()
+		}
+
+		for _, imported := range pkg.Imports() {
+			if name := find(imported); name != "" {
+				return name
+			}
+		}
+
+		return ""
+	}
+
+	if name := find(pkg); name != "" {
+		return name
+	}
+
+	base := path.Base(importPath)
+	if strings.HasPrefix(base, "v") {
+		if _, err := strconv.Atoi(base[1:]); err == nil && path.Dir(importPath) != "." {
+			base = path.Base(path.Dir(importPath))
+		}
+	}
+
+	base = strings.TrimPrefix(base, "go-")
+	if i := strings.IndexFunc(base, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}); i >= 0 {
+		base = base[:i]
+	}
+
+	return base
+}
@@ -0,0 +1,532 @@
+// Package generator generates the code of mutators of Go struct types, like
+// the gomutate command, which only parses flags into Options and writes the
+// generated files.
+package generator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/token"
+	"go/types"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Options are the settings of a generation, overriding those of the
+// configuration files of the packages, like the flags of gomutate.
+type Options struct {
+	// Dir is the directory in which the patterns, files, output, Config and
+	// Templates are resolved, and packages are loaded, the working directory
+	// if empty.
+	Dir string
+	// Patterns are either package patterns, generating the mutators of the
+	// struct types annotated with GenerateDirective of each package, or the
+	// go files of a single package declaring Types. Without patterns, the
+	// package in Dir is loaded as with go generate, and Types may be declared
+	// by any of its files.
+	Patterns []string
+	// Types are the root types, e.g. Acme or Page[Employee], like -type. They
+	// may not be given with package patterns.
+	Types []string
+	// Output is the generated file, like -w. Without files, it defaults to
+	// a file named after the first type, e.g. acme_mutator.go. It may not be
+	// given with package patterns.
+	Output string
+	// OutputPackage is the name of the package of Output when it is not the
+	// package of the types, like -output-package. It may not be given with
+	// package patterns.
+	OutputPackage string
+	// Tags are the comma-separated build tags applied when loading packages.
+	Tags string
+	// Config is the configuration file to read instead of the ConfigFile of
+	// the package directory. With package patterns, it only applies to the
+	// package in its directory, which must be matched by the patterns.
+	Config string
+	// Unexported is set to true to also mutate the unexported fields and
+	// struct types, or to false not to, whatever the configuration files set.
+	// The configuration files apply if nil.
+	Unexported *bool
+	// Interfaces is set to true to also generate an interface and a recording
+	// fake for each mutator, or to false not to, whatever the configuration
+	// files set. The configuration files apply if nil.
+	Interfaces *bool
+	// Templates is the directory of the templates replacing the built-in
+	// ones they are named after, e.g. mutateField.tmpl.
+	Templates string
+	// FieldHandlers generate the methods of the fields of the types they
+	// match, instead of the built-in ones. They have no flag.
+	FieldHandlers []FieldHandler
+	// Package is the expected name of the package loaded without patterns,
+	// like $GOPACKAGE set by go generate, if not empty.
+	Package string
+	// File is the file holding the go:generate directive, like $GOFILE set by
+	// go generate, reported by the errors of the package loaded without
+	// patterns, if not empty. Types need not be declared by File.
+	File string
+}
+
+// ErrInvalidOptions is wrapped by the errors reporting invalid Options, such
+// as files of several directories, or missing types.
+var ErrInvalidOptions = errors.New("invalid options")
+
+// Diagnostic is a problem found while generating, which did not prevent it,
+// like an error in a file of a package not declaring the mutated types.
+type Diagnostic struct {
+	// Pos is the position of the problem, e.g. acme.go:12:3, if known.
+	Pos     string
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	if d.Pos == "" {
+		return d.Message
+	}
+
+	return d.Pos + ": " + d.Message
+}
+
+// Generate generates the mutators given by opts, returning the generated
+// files by name. Without Output nor configured output, the file generated
+// from files is returned with an empty name.
+func Generate(ctx context.Context, opts Options) (map[string][]byte, []Diagnostic, error) {
+	g := &generation{
+		opts:  opts,
+		files: make(map[string][]byte),
+	}
+
+	templates, err := loadTemplates(g.path(opts.Templates))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	g.templates = templates
+
+	if len(opts.Patterns) != 0 && !isFileMode(opts.Patterns) {
+		if len(opts.Types) != 0 || opts.Output != "" || opts.OutputPackage != "" {
+			return nil, nil, fmt.Errorf("%w: types, output and output package cannot be given with package patterns", ErrInvalidOptions)
+		}
+
+		err = g.generatePackages(ctx)
+	} else {
+		err = g.generateFiles(ctx)
+	}
+
+	if err != nil {
+		return nil, g.diagnostics, err
+	}
+
+	return g.files, g.diagnostics, nil
+}
+
+// generation holds the state of a call to Generate.
+type generation struct {
+	opts        Options
+	templates   templateSet
+	files       map[string][]byte
+	diagnostics []Diagnostic
+}
+
+// generateFiles generates the mutators of the types declared by the files of
+// the options, or by the package in the directory of the options without
+// files.
+func (g *generation) generateFiles(ctx context.Context) error {
+	filenames := make([]string, len(g.opts.Patterns))
+	for i, filename := range g.opts.Patterns {
+		filenames[i] = g.path(filename)
+	}
+
+	// get directory of all files, without files the whole package in the
+	// directory is loaded, as when run by go generate
+	directory := g.path(".")
+	generating := len(filenames) == 0
+	if !generating {
+		directory = path.Dir(filenames[0])
+	}
+
+	for _, filename := range filenames {
+		if path.Dir(filename) != directory {
+			return fmt.Errorf("%w: all files must be in the same directory", ErrInvalidOptions)
+		}
+	}
+
+	cfg, err := g.config(directory, g.path(g.opts.Config))
+	if err != nil {
+		return err
+	}
+
+	typeNames := g.opts.Types
+	if len(typeNames) == 0 {
+		typeNames = cfg.Types.names
+	}
+
+	if len(typeNames) == 0 {
+		return fmt.Errorf("%w: no types given", ErrInvalidOptions)
+	}
+
+	output := ""
+	if g.opts.Output != "" {
+		output = g.path(g.opts.Output)
+	} else {
+		output = cfg.output(directory)
+	}
+
+	if output == "" && generating {
+		output = g.path(defaultOutput(typeNames[0]))
+	}
+
+	tags := g.opts.Tags
+	if tags == "" {
+		tags = cfg.Tags
+	}
+
+	loadCfg := loadConfig(ctx, tags)
+	loadCfg.Dir = directory
+
+	pkgs, err := packages.Load(loadCfg, "")
+	if err != nil {
+		return err
+	}
+
+	if len(pkgs) != 1 {
+		return fmt.Errorf("error: %d packages found, expected 1", len(pkgs))
+	}
+
+	pkg := pkgs[0]
+
+	if generating && g.opts.Package != "" && g.opts.Package != pkg.Name {
+		return fmt.Errorf("package %s not found in %s", g.opts.Package, pkg.PkgPath)
+	}
+
+	target, err := outputPackage(pkg, cfg.OutputPackage, output)
+	if err != nil {
+		return err
+	}
+
+	// without files, roots may be declared in any file of the package, which
+	// need not be the file holding the go:generate directive
+	roots := make([]*types.Named, 0, len(typeNames))
+	for _, typeName := range typeNames {
+		named, isNamed := lookupType(pkg, typeName)
+		if !isNamed && generating && g.opts.File != "" {
+			return fmt.Errorf("type %s not found in package %s, generated by %s", typeName, pkg.Name, g.opts.File)
+		}
+
+		if !isNamed {
+			return fmt.Errorf("type %s not found", typeName)
+		}
+
+		rootFile := pkg.Fset.Position(named.Obj().Pos()).Filename
+		if len(filenames) != 0 && !isSelectedFilename(rootFile, filenames) {
+			return fmt.Errorf("type %s not found", typeName)
+		}
+
+		if _, isStruct := named.Underlying().(*types.Struct); !isStruct {
+			return fmt.Errorf("type %s is not a struct", typeName)
+		}
+
+		// types given several times, e.g. Acme,Acme, are generated once
+		if containsType(roots, named) {
+			continue
+		}
+
+		roots = append(roots, named)
+	}
+
+	if err := checkRoots(pkg, target, roots, cfg); err != nil {
+		return err
+	}
+
+	// errors in other files of the package, such as a stale output file,
+	// must not prevent generation
+	if err := g.diagnoseErrors(pkg, append(rootFiles(pkg, roots), filenames...)); err != nil {
+		return err
+	}
+
+	source, err := g.generate(pkg, target, roots, cfg)
+	if err != nil {
+		return err
+	}
+
+	g.files[output] = source
+
+	return nil
+}
+
+// config returns the configuration of the package directory dir, overridden
+// by the options.
+func (g *generation) config(dir, filename string) (config, error) {
+	cfg, err := readConfig(dir, filename)
+	if err != nil {
+		return cfg, err
+	}
+
+	if g.opts.OutputPackage != "" {
+		cfg.OutputPackage = g.opts.OutputPackage
+	}
+
+	if g.opts.Unexported != nil {
+		cfg.Unexported = *g.opts.Unexported
+	}
+
+	if g.opts.Interfaces != nil {
+		cfg.Interfaces = *g.opts.Interfaces
+	}
+
+	return cfg, nil
+}
+
+// checkRoots checks that the roots, declared in pkg, may be mutated from the
+// target package with cfg.
+func checkRoots(pkg *packages.Package, target *types.Package, roots []*types.Named, cfg config) error {
+	if cfg.Unexported && target != pkg.Types {
+		return fmt.Errorf("unexported fields and types cannot be mutated from package %s", target.Name())
+	}
+
+	for _, root := range roots {
+		if !root.Obj().Exported() && !cfg.Unexported {
+			return fmt.Errorf("type %s is not exported, use -unexported to generate its mutator", root.Obj().Name())
+		}
+	}
+
+	return nil
+}
+
+// rootFiles returns the files declaring the roots.
+func rootFiles(pkg *packages.Package, roots []*types.Named) []string {
+	filenames := make([]string, 0, len(roots))
+	for _, root := range roots {
+		filenames = append(filenames, pkg.Fset.Position(root.Obj().Pos()).Filename)
+	}
+
+	return filenames
+}
+
+// diagnoseErrors returns the first error of pkg in one of the files, and
+// reports the errors of other files as diagnostics.
+func (g *generation) diagnoseErrors(pkg *packages.Package, filenames []string) error {
+	for _, pkgErr := range pkg.Errors {
+		filename, _, _ := strings.Cut(pkgErr.Pos, ":")
+		if isSelectedFilename(filename, filenames) {
+			return pkgErr
+		}
+
+		g.diagnose(pkgErr)
+	}
+
+	return nil
+}
+
+// path resolves filename in the directory of the options, if not empty.
+func (g *generation) path(filename string) string {
+	if g.opts.Dir == "" || filename == "" || filepath.IsAbs(filename) {
+		return filename
+	}
+
+	return filepath.Join(g.opts.Dir, filename)
+}
+
+func (g *generation) diagnose(pkgErr packages.Error) {
+	g.diagnostics = append(g.diagnostics, Diagnostic{
+		Pos:     pkgErr.Pos,
+		Message: pkgErr.Msg,
+	})
+}
+
+// loadConfig returns the configuration loading packages with the
+// comma-separated build tags.
+func loadConfig(ctx context.Context, tags string) *packages.Config {
+	loadAllSyntax := packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedImports | packages.NeedTypes | packages.NeedTypesSizes | packages.NeedSyntax | packages.NeedTypesInfo | packages.NeedModule
+
+	cfg := &packages.Config{
+		Context: ctx,
+		Mode:    loadAllSyntax,
+		Tests:   false,
+	}
+	if tags != "" {
+		cfg.BuildFlags = []string{"-tags=" + tags}
+	}
+
+	return cfg
+}
+
+// generate returns the source of the mutators of the roots, declared in pkg,
+// generated into the output package, following the field options and
+// formatting of cfg.
+func (g *generation) generate(pkg *packages.Package, output *types.Package, roots []*types.Named, cfg config) ([]byte, error) {
+	module := ""
+	if pkg.Module != nil {
+		module = pkg.Module.Path
+	}
+
+	// fmt and reflect are referred to by fakes and equalExpr, other packages
+	// are imported on demand, named after the packages of the types
+	imports := newImportSet("fmt", "reflect", "github.com/pdcalado/gomutate/changes")
+
+	handler := newHandler(pkg.Types, output, module, imports, cfg, fieldComments(pkg.Syntax), g.opts.FieldHandlers)
+
+	handlerSteps, err := handler.handle(roots)
+	if err != nil {
+		return nil, err
+	}
+
+	var templateSteps []templateStep
+
+	rootMutators := make(map[string]bool, len(roots))
+	for _, root := range roots {
+		data := handler.mutatorData(root)
+		rootMutators[data.Mutator] = true
+
+		templateSteps = append(templateSteps, templateStep{
+			template: &mainMutatorTemplate,
+			data:     data,
+		})
+	}
+
+	for _, data := range handler.handled {
+		if rootMutators[data.Mutator] {
+			continue
+		}
+
+		templateSteps = append(templateSteps, templateStep{
+			template: &subMutatorTemplate,
+			data:     data,
+		})
+	}
+
+	templateSteps = append(templateSteps, handlerSteps...)
+
+	var body bytes.Buffer
+	if err := executeSteps(&body, templateSteps, g.templates); err != nil {
+		return nil, err
+	}
+
+	code := body.Bytes()
+	if cfg.Interfaces {
+		if code, err = addDoubles(output.Name(), code, handler.naming, g.templates); err != nil {
+			return nil, err
+		}
+	}
+
+	source, err := render(output.Name(), imports, code, cfg.format(), g.templates)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkMethods(pkg, source, output == pkg.Types); err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// outputPackage returns the package of the generated code, written to
+// filename, which is pkg unless name is set. The import path of the output
+// package is derived from the module of pkg, and is only known if filename
+// is given.
+func outputPackage(pkg *packages.Package, name, filename string) (*types.Package, error) {
+	if name == "" || name == pkg.Name {
+		return pkg.Types, nil
+	}
+
+	if !token.IsIdentifier(name) {
+		return nil, fmt.Errorf("invalid output package name %q", name)
+	}
+
+	if filename == "" || pkg.Module == nil || pkg.Module.Dir == "" {
+		return types.NewPackage(name, name), nil
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(filename))
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := filepath.Rel(pkg.Module.Dir, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("output file %s is not in module %s", filename, pkg.Module.Path)
+	}
+
+	importPath := path.Join(pkg.Module.Path, filepath.ToSlash(rel))
+	if importPath == pkg.PkgPath {
+		return nil, fmt.Errorf("output file %s is in package %s, not %s", filename, pkg.Name, name)
+	}
+
+	return types.NewPackage(importPath, name), nil
+}
+
+// lookupType returns the named type declared in the package scope, or its
+// instantiation if typeName has type arguments, e.g. Page[Employee].
+// Generic types without type arguments are returned as declared.
+func lookupType(pkg *packages.Package, typeName string) (*types.Named, bool) {
+	if !strings.Contains(typeName, "[") {
+		obj, isTypeName := pkg.Types.Scope().Lookup(typeName).(*types.TypeName)
+		if !isTypeName {
+			return nil, false
+		}
+
+		named, isNamed := obj.Type().(*types.Named)
+		return named, isNamed
+	}
+
+	tv, err := types.Eval(pkg.Fset, pkg.Types, token.NoPos, typeName)
+	if err != nil || !tv.IsType() {
+		return nil, false
+	}
+
+	named, isNamed := tv.Type.(*types.Named)
+	return named, isNamed
+}
+
+// defaultOutput names the output file after the type when no files are given,
+// e.g. acme_mutator.go for Acme, or page_employee_mutator.go for Page[Employee].
+func defaultOutput(typeName string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		case r == '[' || r == ',' || r == '_':
+			return '_'
+		default:
+			return -1
+		}
+	}, typeName)
+
+	return strings.Trim(name, "_") + OutputSuffix
+}
+
+// isFileMode reports whether args are go files, rather than package patterns.
+func isFileMode(args []string) bool {
+	for _, arg := range args {
+		if strings.HasSuffix(arg, ".go") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSelectedFilename(file string, list []string) bool {
+	for _, item := range list {
+		itemPath, err := filepath.Abs(item)
+		if err != nil {
+			continue
+		}
+
+		filePath, err := filepath.Abs(file)
+		if err != nil {
+			continue
+		}
+
+		if filePath == itemPath {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,1124 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pdcalado/gomutate/internal/tags"
+)
+
+type handler struct {
+	pkg          *types.Package // package declaring the roots
+	output       *types.Package // package of the generated code, which may be pkg
+	module       string
+	imports      *importSet
+	handledTypes map[string]string // type of each generated type, by name
+	handled      []mutatorData
+	prefixes     map[string]string // display name of each prefix, see addPrefix
+	setters      map[string][]setterData
+	fieldTags    map[string]string // options replacing struct tags, by Type.Field
+	comments     map[token.Pos]fieldComment
+	unexported   bool                         // whether unexported fields and types are mutated
+	methods      map[string]map[string]string // field of each method name, by mutator
+	naming       naming
+	typeNaming   map[string]naming // naming of the types of pkg with their own, by name
+	// fieldHandlers claim fields before the built-in handling, usedHandlers
+	// tells those whose imports and helpers were generated, by index.
+	fieldHandlers []FieldHandler
+	usedHandlers  map[int]bool
+	steps         []templateStep
+	err           error // first error found, e.g. an invalid struct tag
+}
+
+// setterData describes a setter generated for a mutator, which may be promoted
+// to the mutators of types embedding the mutated type.
+type setterData struct {
+	FieldName string
+	// Method is the field name in the name of the setter.
+	Method string
+	// ByPointer is set if the setter takes a pointer to the field type.
+	ByPointer bool
+	Field     fieldData
+}
+
+// newHandler creates a handler generating code into output for the types of
+// pkg, which may be the same package. Struct types of other packages are
+// chained only if they belong to module, which may be empty.
+// The field options of cfg replace those of struct tags, and the comments of
+// the fields of pkg are given to templates. Fields whose types are matched by
+// fieldHandlers are handled by them.
+func newHandler(
+	pkg *types.Package,
+	output *types.Package,
+	module string,
+	imports *importSet,
+	cfg config,
+	comments map[token.Pos]fieldComment,
+	fieldHandlers []FieldHandler,
+) *handler {
+	return &handler{
+		pkg:           pkg,
+		output:        output,
+		module:        module,
+		imports:       imports,
+		handledTypes:  make(map[string]string),
+		prefixes:      make(map[string]string),
+		setters:       make(map[string][]setterData),
+		fieldTags:     cfg.fieldTags(),
+		comments:      comments,
+		unexported:    cfg.Unexported && pkg == output,
+		methods:       make(map[string]map[string]string),
+		naming:        cfg.Naming.withDefaults(),
+		typeNaming:    cfg.typeNaming(),
+		fieldHandlers: fieldHandlers,
+		usedHandlers:  make(map[int]bool),
+	}
+}
+
+// handle may only be called once, with all the root types.
+// Struct types reachable from several roots are handled only once.
+func (h *handler) handle(roots []*types.Named) ([]templateStep, error) {
+	for _, root := range roots {
+		h.handleStructType(root)
+	}
+
+	if h.err != nil {
+		return nil, h.err
+	}
+
+	names := h.prefixNames()
+
+	prefixes := make([]prefixData, 0, len(h.prefixes))
+	for key, value := range h.prefixes {
+		prefixes = append(prefixes, prefixData{
+			ConstName:  fmt.Sprintf("MutationPrefix%s", names[key]),
+			ConstValue: value,
+		})
+	}
+	sort.SliceStable(prefixes, func(i, j int) bool {
+		return prefixes[i].ConstName < prefixes[j].ConstName
+	})
+
+	// the steps were given the keys of the prefixes, not the constant names
+	for i, step := range h.steps {
+		if data, isField := step.data.(mutateFunctionData); isField && data.Prefix != "" {
+			data.Prefix = names[data.Prefix]
+			h.steps[i].data = data
+		}
+	}
+
+	return append([]templateStep{
+		{
+			template: &fieldNamesTemplate,
+			data:     prefixes,
+		},
+	}, h.steps...), nil
+}
+
+func (h *handler) handleStructType(named *types.Named) {
+	// generic mutators are generated from the generic type declaration
+	if isGeneric(named) {
+		named = named.Origin()
+	}
+
+	owner := h.mutatorData(named)
+
+	if !h.claimType(owner.MutatorName, owner.TypeName) {
+		return
+	}
+
+	h.handled = append(h.handled, owner)
+
+	fields := h.fields(named, owner)
+
+	for _, field := range fields {
+		fieldType := field.Type()
+
+		// prefixes are identified by the struct type declaring the field, see
+		// addPrefix
+		fieldPrefix := owner.Name + "." + field.method()
+
+		if field.options.NoEnum && len(h.enumConstants(fieldType)) == 0 {
+			h.fail(fmt.Errorf("field %s of %s has the noenum option, but %s is not an enum", field.Name(), owner.TypeName, h.typeName(fieldType)))
+			continue
+		}
+
+		var toAppend []templateStep
+
+		custom := h.fieldHandler(fieldType)
+		if custom >= 0 {
+			toAppend = h.handleCustom(owner, field, fieldType, custom)
+		} else {
+			switch fieldType.(type) {
+			case *types.Slice:
+				if isByteSlice(fieldType) {
+					toAppend = h.handleByteSlice(owner, field, fieldType)
+				} else {
+					toAppend = h.handleSlice(owner, field, fieldType, fieldPrefix)
+				}
+			case *types.Map:
+				toAppend = h.handleMap(owner, field, fieldType, fieldPrefix)
+			case *types.Pointer:
+				toAppend = h.handlePointer(owner, field, fieldType, fieldPrefix)
+			default:
+				if h.chainedStruct(fieldType) != nil { // may be a struct non-pointer type
+					toAppend = h.handleObject(owner, field, fieldType, fieldPrefix)
+				} else if types.IsInterface(fieldType) {
+					toAppend = h.handleInterface(owner, field, fieldType, fieldPrefix)
+				} else if !field.options.NoEnum && len(h.enumConstants(fieldType)) > 0 {
+					toAppend = h.handleEnumField(owner, field, fieldType)
+				} else {
+					toAppend = h.handleOther(owner, field, fieldType)
+				}
+			}
+		}
+
+		metadata := h.fieldMetadata(field)
+		for j := range toAppend {
+			if data, isField := toAppend[j].data.(mutateFunctionData); isField {
+				data.Method = field.method()
+				data.Names = h.namingOf(named).methods(field.method())
+				data.Field = metadata
+				toAppend[j].data = data
+			}
+		}
+
+		if field.Embedded() && field.options.Navigable() && custom < 0 {
+			toAppend = append(toAppend, h.handlePromoted(named, owner, field)...)
+		}
+
+		h.steps = append(h.steps, toAppend...)
+	}
+}
+
+func (h *handler) handleSlice(
+	owner mutatorData,
+	field fieldInfo,
+	fieldType types.Type,
+	prefix string,
+) []templateStep {
+	elemType := fieldType.(*types.Slice).Elem()
+	_, fieldTypeIsPointer := elemType.Underlying().(*types.Pointer)
+
+	var steps []templateStep
+
+	if field.options.Settable() {
+		h.addSetter(owner, field, false)
+
+		steps = append(steps, templateStep{
+			template: &mapOrSliceSetTemplate,
+			data: mutateFunctionData{
+				TypeName:      owner.TypeName,
+				Mutator:       owner.Mutator,
+				FieldName:     field.Name(),
+				DisplayName:   field.displayName(),
+				FieldTypeName: h.typeName(fieldType),
+				Immutable:     field.options.Immutable,
+			},
+		})
+	}
+
+	if field.options.Mutable() {
+		steps = append(steps, templateStep{
+			template: &sliceAppendTemplate,
+			data: mutateFunctionData{
+				TypeName:          owner.TypeName,
+				Mutator:           owner.Mutator,
+				FieldName:         field.Name(),
+				DisplayName:       field.displayName(),
+				FieldElemTypeName: h.typeName(elemType),
+			},
+		})
+	}
+
+	if !field.options.Navigable() {
+		return steps
+	}
+
+	if container := h.handleContainer(elemType); container != "" {
+		h.addPrefix(prefix, field)
+
+		return append(steps, templateStep{
+			template: &mutateNestedSliceElementTemplate,
+			data: mutateFunctionData{
+				TypeName:          owner.TypeName,
+				Mutator:           owner.Mutator,
+				FieldName:         field.Name(),
+				FieldElemTypeName: h.typeName(elemType),
+				FieldMutator:      container,
+				Prefix:            prefix,
+			},
+		})
+	}
+
+	chained := h.chain(elemType)
+	if chained == nil {
+		return steps
+	}
+
+	h.addPrefix(prefix, field)
+
+	return append(steps, templateStep{
+		template: &mutateSliceElementTemplate,
+		data: mutateFunctionData{
+			TypeName:           owner.TypeName,
+			Mutator:            owner.Mutator,
+			FieldName:          field.Name(),
+			FieldElemTypeName:  h.typeName(elemType),
+			FieldTypeIsPointer: fieldTypeIsPointer,
+			FieldMutator:       h.mutatorRef(chained),
+			Prefix:             prefix,
+		},
+	})
+}
+
+func (h *handler) handleMap(
+	owner mutatorData,
+	field fieldInfo,
+	fieldType types.Type,
+	prefix string,
+) []templateStep {
+	elemType := fieldType.(*types.Map).Elem()
+	fieldKeyType := fieldType.(*types.Map).Key()
+
+	var steps []templateStep
+
+	if field.options.Settable() {
+		h.addSetter(owner, field, false)
+
+		steps = append(steps, templateStep{
+			template: &mapOrSliceSetTemplate,
+			data: mutateFunctionData{
+				TypeName:      owner.TypeName,
+				Mutator:       owner.Mutator,
+				FieldName:     field.Name(),
+				DisplayName:   field.displayName(),
+				FieldTypeName: h.typeName(fieldType),
+				Immutable:     field.options.Immutable,
+			},
+		})
+	}
+
+	if field.options.Mutable() {
+		steps = append(steps, templateStep{
+			template: &mapInsertTemplate,
+			data: mutateFunctionData{
+				TypeName:          owner.TypeName,
+				Mutator:           owner.Mutator,
+				FieldName:         field.Name(),
+				DisplayName:       field.displayName(),
+				FieldKeyTypeName:  h.typeName(fieldKeyType),
+				FieldTypeName:     h.typeName(fieldType),
+				FieldElemTypeName: h.typeName(elemType),
+				FieldElemEqual:    equalExpr(elemType, "currentValue", "value"),
+			},
+		})
+	}
+
+	if !field.options.Navigable() {
+		return steps
+	}
+
+	if container := h.handleContainer(elemType); container != "" {
+		h.addPrefix(prefix, field)
+
+		return append(steps, templateStep{
+			template: &mutateNestedMapElementTemplate,
+			data: mutateFunctionData{
+				TypeName:          owner.TypeName,
+				Mutator:           owner.Mutator,
+				FieldName:         field.Name(),
+				FieldKeyTypeName:  h.typeName(fieldKeyType),
+				FieldTypeName:     h.typeName(fieldType),
+				FieldElemTypeName: h.typeName(elemType),
+				FieldMutator:      container,
+				Prefix:            prefix,
+			},
+		})
+	}
+
+	if !chainableElem(elemType) {
+		return steps
+	}
+
+	chained := h.chain(elemType)
+	if chained == nil {
+		return steps
+	}
+
+	h.addPrefix(prefix, field)
+
+	return append(steps, templateStep{
+		template: &mutateMapElementTemplate,
+		data: mutateFunctionData{
+			TypeName:         owner.TypeName,
+			Mutator:          owner.Mutator,
+			FieldName:        field.Name(),
+			FieldKeyTypeName: h.typeName(fieldKeyType),
+			FieldMutator:     h.mutatorRef(chained),
+			Prefix:           prefix,
+		},
+	})
+}
+
+// claimType reports whether the generated type name is claimed for the
+// first time, by typeName. Names claimed by another type are reported, e.g.
+// unexported type names are capitalized, so that acme and Acme are both
+// mutated by MutatorAcme, and the mutator of []Inner is named like the one of
+// a struct named SliceInner.
+func (h *handler) claimType(name, typeName string) bool {
+	claimed, exists := h.handledTypes[name]
+	if !exists {
+		h.handledTypes[name] = typeName
+		return true
+	}
+
+	if claimed != typeName {
+		h.fail(fmt.Errorf("types %s and %s are both mutated by %s", claimed, typeName, name))
+	}
+
+	return false
+}
+
+// handleContainer generates the mutator of a slice or map nested in another
+// slice or map, returning its name, or an empty string if t is not a slice or
+// map, or if its type cannot be named.
+func (h *handler) handleContainer(t types.Type) string {
+	if !isContainer(t) {
+		return ""
+	}
+
+	ident, isNamed := h.typeIdent(t)
+	if !isNamed {
+		return ""
+	}
+
+	mutator := h.naming.Mutator + ident
+	if !h.claimType(mutator, h.typeName(t)) {
+		return mutator
+	}
+
+	var (
+		keyType  types.Type
+		elemType types.Type
+		template = &sliceMutatorTemplate
+		method   = h.naming.methods("").At
+		keyName  = "index"
+		isMap    = false
+	)
+
+	switch v := t.(type) {
+	case *types.Slice:
+		keyType = types.Typ[types.Int]
+		elemType = v.Elem()
+	case *types.Map:
+		keyType = v.Key()
+		elemType = v.Elem()
+		template = &mapMutatorTemplate
+		method = h.naming.methods("").WithKey
+		keyName = "key"
+		isMap = true
+	}
+
+	h.steps = append(h.steps, templateStep{
+		template: template,
+		data: containerData{
+			TypeName:     h.typeName(t),
+			Mutator:      mutator,
+			KeyTypeName:  h.typeName(keyType),
+			ElemTypeName: h.typeName(elemType),
+			ElemEqual:    equalExpr(elemType, "currentValue", "value"),
+			Names:        h.naming.methods(""),
+		},
+	})
+
+	element := containerElementData{
+		TypeName:     h.typeName(t),
+		Mutator:      mutator,
+		Method:       method,
+		KeyName:      keyName,
+		KeyTypeName:  h.typeName(keyType),
+		ElemTypeName: h.typeName(elemType),
+		IsMap:        isMap,
+	}
+
+	if container := h.handleContainer(elemType); container != "" {
+		element.ElemMutator = container
+
+		h.steps = append(h.steps, templateStep{
+			template: &containerNestedElementTemplate,
+			data:     element,
+		})
+
+		return mutator
+	}
+
+	_, element.ElemIsPointer = elemType.Underlying().(*types.Pointer)
+
+	if isMap && !chainableElem(elemType) {
+		return mutator
+	}
+
+	chained := h.chain(elemType)
+	if chained == nil {
+		return mutator
+	}
+
+	element.ElemMutator = h.mutatorRef(chained)
+
+	h.steps = append(h.steps, templateStep{
+		template: &containerStructElementTemplate,
+		data:     element,
+	})
+
+	return mutator
+}
+
+func (h *handler) handlePointer(
+	owner mutatorData,
+	field fieldInfo,
+	fieldType types.Type,
+	prefix string,
+) []templateStep {
+	var steps []templateStep
+
+	if field.options.Settable() {
+		h.addSetter(owner, field, false)
+
+		steps = append(steps, templateStep{
+			template: &mutateSetPtrTemplate,
+			data: mutateFunctionData{
+				TypeName:      owner.TypeName,
+				Mutator:       owner.Mutator,
+				FieldName:     field.Name(),
+				DisplayName:   field.displayName(),
+				FieldTypeName: h.typeName(fieldType),
+				FieldEqual:    equalExpr(fieldType, "m.inner."+field.Name(), "value"),
+				Immutable:     field.options.Immutable,
+			},
+		})
+	}
+
+	if !field.options.Navigable() {
+		return steps
+	}
+
+	chained := h.chain(fieldType)
+	if chained == nil {
+		return steps
+	}
+
+	h.addPrefix(prefix, field)
+
+	return append(steps, templateStep{
+		template: &mutatePtrTemplate,
+		data: mutateFunctionData{
+			TypeName:      owner.TypeName,
+			Mutator:       owner.Mutator,
+			FieldName:     field.Name(),
+			FieldTypeName: h.baseTypeName(fieldType),
+			FieldMutator:  h.mutatorRef(chained),
+			Prefix:        prefix,
+		},
+	})
+}
+
+func (h *handler) handleObject(
+	owner mutatorData,
+	field fieldInfo,
+	fieldType types.Type,
+	prefix string,
+) []templateStep {
+	var steps []templateStep
+
+	if field.options.Settable() {
+		h.addSetter(owner, field, true)
+
+		steps = append(steps, templateStep{
+			template: &mutateSetObjTemplate,
+			data: mutateFunctionData{
+				TypeName:      owner.TypeName,
+				Mutator:       owner.Mutator,
+				FieldName:     field.Name(),
+				DisplayName:   field.displayName(),
+				FieldTypeName: h.typeName(fieldType),
+				Immutable:     field.options.Immutable,
+			},
+		})
+	}
+
+	if !field.options.Navigable() {
+		return steps
+	}
+
+	chained := h.chain(fieldType)
+
+	h.addPrefix(prefix, field)
+
+	return append(steps, templateStep{
+		template: &mutateObjTemplate,
+		data: mutateFunctionData{
+			TypeName:      owner.TypeName,
+			Mutator:       owner.Mutator,
+			FieldName:     field.Name(),
+			FieldTypeName: h.baseTypeName(fieldType),
+			FieldMutator:  h.mutatorRef(chained),
+			Prefix:        prefix,
+		},
+	})
+}
+
+func (h *handler) handleOther(
+	owner mutatorData,
+	field fieldInfo,
+	fieldType types.Type,
+) []templateStep {
+	if !field.options.Settable() {
+		return nil
+	}
+
+	h.addSetter(owner, field, false)
+
+	return []templateStep{
+		{
+			template: &mutateFieldTemplate,
+			data: mutateFunctionData{
+				TypeName:      owner.TypeName,
+				Mutator:       owner.Mutator,
+				FieldName:     field.Name(),
+				DisplayName:   field.displayName(),
+				FieldTypeName: h.typeName(fieldType),
+				FieldEqual:    equalExpr(fieldType, "m.inner."+field.Name(), "value"),
+				Immutable:     field.options.Immutable,
+			},
+		},
+	}
+}
+
+func (h *handler) handleByteSlice(
+	owner mutatorData,
+	field fieldInfo,
+	fieldType types.Type,
+) []templateStep {
+	if !field.options.Settable() {
+		return nil
+	}
+
+	h.addSetter(owner, field, false)
+
+	return []templateStep{
+		{
+			template: &mutateByteSliceTemplate,
+			data: mutateFunctionData{
+				TypeName:      owner.TypeName,
+				Mutator:       owner.Mutator,
+				FieldName:     field.Name(),
+				DisplayName:   field.displayName(),
+				FieldTypeName: h.typeName(fieldType),
+				Immutable:     field.options.Immutable,
+			},
+		},
+	}
+}
+
+// fields returns the fields of named for which code is generated, reporting
+// fields whose methods would have the same names.
+func (h *handler) fields(named *types.Named, owner mutatorData) []fieldInfo {
+	structType := named.Underlying().(*types.Struct)
+
+	methods := make(map[string]string)
+	h.methods[owner.Mutator] = methods
+
+	var fields []fieldInfo
+
+	for i := 0; i < structType.NumFields(); i++ {
+		options, err := h.fieldOptions(named, i)
+		if err != nil {
+			h.fail(fmt.Errorf("invalid %s tag of field %s of %s: %w", tags.Key, structType.Field(i).Name(), owner.TypeName, err))
+			continue
+		}
+
+		if options.Skip {
+			continue
+		}
+
+		field := fieldInfo{Var: structType.Field(i), options: options, tag: structType.Tag(i), index: i}
+
+		// embedded fields are only mutated if they are chained structs
+		if field.Embedded() && h.chainedStruct(field.Type()) == nil {
+			continue
+		}
+
+		// fields of other packages are only reachable if exported, and
+		// unexported fields are only mutated if requested
+		if !field.Exported() && (named.Obj().Pkg() != h.output || !h.unexported) {
+			continue
+		}
+
+		if named.Obj().Pkg() != h.output && !h.isAccessible(field.Type()) {
+			continue
+		}
+
+		if other, exists := methods[field.method()]; exists {
+			h.fail(fmt.Errorf("fields %s and %s of %s would both be mutated by methods named after %s", other, field.Name(), owner.TypeName, field.method()))
+			continue
+		}
+
+		methods[field.method()] = field.Name()
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// addPrefix declares the prefix constant of the changes made through the
+// mutators chained from field, identified by prefix, e.g.
+// Employee.Projects. Prefixes are identified by the struct type declaring the
+// field, not by the path from the roots, so that types reachable through
+// several fields or roots, and recursive types, have one prefix per field
+// whichever path is handled first. Constants are named by prefixNames.
+func (h *handler) addPrefix(prefix string, field fieldInfo) {
+	h.prefixes[prefix] = field.displayName()
+}
+
+// prefixNames returns the names of the prefix constants by prefix, which are
+// the names of the struct type and field, e.g. EmployeeProjects. Prefixes
+// whose names would clash, like those of Order.LineItems and OrderLine.Items,
+// are named with a separator, Order_LineItems and OrderLine_Items, and with a
+// number if still taken. Names only depend on the set of prefixes, not on the
+// order in which they were handled.
+func (h *handler) prefixNames() map[string]string {
+	keys := make([]string, 0, len(h.prefixes))
+	joined := make(map[string]int, len(h.prefixes))
+	for key := range h.prefixes {
+		keys = append(keys, key)
+		joined[strings.Replace(key, ".", "", 1)]++
+	}
+	sort.Strings(keys)
+
+	names := make(map[string]string, len(keys))
+	taken := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if name := strings.Replace(key, ".", "", 1); joined[name] == 1 {
+			names[key] = name
+			taken[name] = true
+		}
+	}
+
+	for _, key := range keys {
+		if _, named := names[key]; named {
+			continue
+		}
+
+		base := strings.Replace(key, ".", "_", 1)
+		name := base
+		for i := 2; taken[name]; i++ {
+			name = fmt.Sprintf("%s%d", base, i)
+		}
+
+		names[key] = name
+		taken[name] = true
+	}
+
+	return names
+}
+
+// fail records err, unless an error was already found.
+func (h *handler) fail(err error) {
+	if h.err == nil {
+		h.err = err
+	}
+}
+
+// handlePromoted generates the setters promoted from the struct embedded
+// by field of named, delegating to the mutator of the embedded field so that
+// changes are attributed to the embedded field.
+func (h *handler) handlePromoted(
+	named *types.Named,
+	owner mutatorData,
+	field fieldInfo,
+) []templateStep {
+	embeddedType := h.chainedStruct(field.Type())
+	embedded := h.mutatorData(embeddedType)
+
+	var steps []templateStep
+
+	for _, setter := range h.setters[embedded.Mutator] {
+		// skip fields shadowed by the embedding type or ambiguous at the same depth
+		obj, path, _ := types.LookupFieldOrMethod(named, true, h.output, setter.FieldName)
+		promoted, isField := obj.(*types.Var)
+		if !isField || len(path) < 2 || path[0] != field.index {
+			continue
+		}
+
+		// skip setters whose names are taken by other fields, which differ
+		// from the promoted field only by the case of their first letter
+		if _, exists := h.methods[owner.Mutator][setter.Method]; exists {
+			continue
+		}
+
+		h.methods[owner.Mutator][setter.Method] = setter.FieldName
+		h.setters[owner.Mutator] = append(h.setters[owner.Mutator], setter)
+
+		// the promoted field type is given in terms of the embedding type,
+		// which matters for fields of generic types
+		valueTypeName := h.typeName(promoted.Type())
+		if setter.ByPointer {
+			valueTypeName = "*" + valueTypeName
+		}
+
+		steps = append(steps, templateStep{
+			template: &promotedSetterTemplate,
+			data: mutateFunctionData{
+				TypeName:       owner.TypeName,
+				Mutator:        owner.Mutator,
+				FieldName:      setter.FieldName,
+				Method:         setter.Method,
+				FieldTypeName:  valueTypeName,
+				EmbeddedName:   field.Name(),
+				EmbeddedMethod: field.method(),
+				EmbeddedSet:    h.namingOf(embeddedType).methods(setter.Method).Set,
+				Names:          h.namingOf(named).methods(setter.Method),
+				Field:          setter.Field,
+			},
+		})
+	}
+
+	return steps
+}
+
+// namingOf returns the naming of the methods of the mutator of named, which
+// is the naming of the package unless named has its own.
+func (h *handler) namingOf(named *types.Named) naming {
+	if named.Obj().Pkg() == h.pkg {
+		if typeNaming, exists := h.typeNaming[named.Obj().Name()]; exists {
+			return typeNaming
+		}
+	}
+
+	return h.naming
+}
+
+func (h *handler) addSetter(owner mutatorData, field fieldInfo, byPointer bool) {
+	h.setters[owner.Mutator] = append(h.setters[owner.Mutator], setterData{
+		FieldName: field.Name(),
+		Method:    field.method(),
+		ByPointer: byPointer,
+		Field:     h.fieldMetadata(field),
+	})
+}
+
+// mutatorData names the mutator of a struct type. Types of other packages are
+// named after their import, e.g. MutatorBillingAccount for billing.Account,
+// and instantiations of generic types after their type arguments, e.g.
+// MutatorPageEmployee for Page[Employee]. Generic types instantiated with type
+// parameters get a generic mutator, e.g. MutatorPage[T] for Page[T].
+func (h *handler) mutatorData(named *types.Named) mutatorData {
+	name := exportedName(named.Obj().Name())
+	if pkg := named.Obj().Pkg(); pkg != h.pkg {
+		name = exportedName(h.imports.name(pkg)) + name
+	}
+
+	if isGeneric(named) {
+		params := named.Origin().TypeParams()
+		names := make([]string, params.Len())
+		decls := make([]string, params.Len())
+		for i := range names {
+			names[i] = params.At(i).Obj().Name()
+			decls[i] = names[i] + " " + h.typeName(params.At(i).Constraint())
+		}
+
+		typeName := h.objectName(named.Obj())
+		typeArgs := "[" + strings.Join(names, ", ") + "]"
+
+		return mutatorData{
+			TypeName:    typeName + typeArgs,
+			Name:        name,
+			Mutator:     h.naming.Mutator + name + typeArgs,
+			MutatorName: h.naming.Mutator + name,
+			TypeParams:  "[" + strings.Join(decls, ", ") + "]",
+			Constructor: h.naming.Constructor + name,
+		}
+	}
+
+	for i := 0; i < named.TypeArgs().Len(); i++ {
+		ident, _ := h.typeIdent(named.TypeArgs().At(i))
+		name += ident
+	}
+
+	return mutatorData{
+		TypeName:    h.typeName(named),
+		Name:        name,
+		Mutator:     h.naming.Mutator + name,
+		MutatorName: h.naming.Mutator + name,
+		Constructor: h.naming.Constructor + name,
+	}
+}
+
+// mutatorRef returns the mutator type of a struct type as referred to by other
+// mutators, instantiating generic mutators with the type arguments of named.
+func (h *handler) mutatorRef(named *types.Named) string {
+	data := h.mutatorData(named)
+	if !isGeneric(named) || named.TypeArgs().Len() == 0 {
+		return data.Mutator
+	}
+
+	args := make([]string, named.TypeArgs().Len())
+	for i := range args {
+		args[i] = h.typeName(named.TypeArgs().At(i))
+	}
+
+	return data.MutatorName + "[" + strings.Join(args, ", ") + "]"
+}
+
+// chain returns the struct type for which a mutator is chained when mutating
+// a value of type t, or nil if the value can only be set. t must be a struct
+// or a pointer to a struct, and the chained mutator is handled if needed.
+func (h *handler) chain(t types.Type) *types.Named {
+	chained := h.chainedStruct(t)
+	if chained != nil {
+		h.handleStructType(chained)
+	}
+
+	return chained
+}
+
+// chainedStruct returns the struct type for which a mutator is chained when
+// mutating a value of type t, or nil if the value can only be set.
+// Struct types must be declared in the generated package, or be exported by
+// another package of the module with at least one exported field.
+func (h *handler) chainedStruct(t types.Type) *types.Named {
+	if pointer, isPointer := t.(*types.Pointer); isPointer {
+		t = pointer.Elem()
+	}
+
+	named, isNamed := t.(*types.Named)
+	if !isNamed {
+		return nil
+	}
+	structType, isStruct := named.Underlying().(*types.Struct)
+	if !isStruct {
+		return nil
+	}
+
+	pkg := named.Obj().Pkg()
+	if pkg == h.output {
+		if !named.Obj().Exported() && !h.unexported {
+			return nil
+		}
+
+		return named
+	}
+
+	if pkg != h.pkg && !h.inModule(pkg) || !named.Obj().Exported() {
+		return nil
+	}
+
+	for i := 0; i < structType.NumFields(); i++ {
+		if structType.Field(i).Exported() {
+			return named
+		}
+	}
+
+	return nil
+}
+
+// inModule reports whether pkg is another package of the module.
+func (h *handler) inModule(pkg *types.Package) bool {
+	if pkg == nil || h.module == "" {
+		return false
+	}
+
+	return pkg.Path() == h.module || strings.HasPrefix(pkg.Path(), h.module+"/")
+}
+
+// isAccessible reports whether t can be referred to from the generated package.
+func (h *handler) isAccessible(t types.Type) bool {
+	switch v := t.(type) {
+	case *types.Slice:
+		return h.isAccessible(v.Elem())
+	case *types.Array:
+		return h.isAccessible(v.Elem())
+	case *types.Pointer:
+		return h.isAccessible(v.Elem())
+	case *types.Map:
+		return h.isAccessible(v.Key()) && h.isAccessible(v.Elem())
+	case *types.Named:
+		pkg := v.Obj().Pkg()
+		return pkg == nil || pkg == h.output || v.Obj().Exported()
+	default:
+		return true
+	}
+}
+
+// qualifier omits the package name for types of the generated package,
+// and records the imports needed for types of other packages.
+func (h *handler) qualifier(pkg *types.Package) string {
+	if pkg == h.output {
+		return ""
+	}
+
+	return h.imports.name(pkg)
+}
+
+// objectName returns the name of the object as written in the generated package.
+func (h *handler) objectName(obj types.Object) string {
+	if qualifier := h.qualifier(obj.Pkg()); qualifier != "" {
+		return qualifier + "." + obj.Name()
+	}
+
+	return obj.Name()
+}
+
+// typeName returns the name of the type as written in the generated package.
+func (h *handler) typeName(t types.Type) string {
+	return types.TypeString(t, h.qualifier)
+}
+
+// baseTypeName returns the name of the innermost element type of slices,
+// arrays, maps and pointers, as written in the generated package.
+func (h *handler) baseTypeName(t types.Type) string {
+	return h.typeName(baseType(t))
+}
+
+// baseType returns the innermost element type of slices, arrays, maps and pointers.
+func baseType(t types.Type) types.Type {
+	for {
+		switch v := t.(type) {
+		case *types.Slice:
+			t = v.Elem()
+		case *types.Array:
+			t = v.Elem()
+		case *types.Map:
+			t = v.Elem()
+		case *types.Pointer:
+			t = v.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+// typeIdent returns an identifier for t, used to name the mutators of nested
+// slices and maps, e.g. SliceString for []string.
+func (h *handler) typeIdent(t types.Type) (string, bool) {
+	// mutators of nested slices and maps are never generic
+	if hasTypeParam(t) {
+		return "", false
+	}
+
+	switch v := t.(type) {
+	case *types.Basic:
+		return exportedName(v.Name()), true
+	case *types.Named:
+		if chained := h.chainedStruct(v); chained != nil {
+			return h.mutatorData(chained).Name, true
+		}
+
+		name := exportedName(v.Obj().Name())
+		if pkg := v.Obj().Pkg(); pkg != nil && pkg != h.pkg {
+			name = exportedName(h.imports.name(pkg)) + v.Obj().Name()
+		}
+
+		ok := true
+		for i := 0; i < v.TypeArgs().Len(); i++ {
+			arg, argOk := h.typeIdent(v.TypeArgs().At(i))
+			name += arg
+			ok = ok && argOk
+		}
+
+		return name, ok
+	case *types.Pointer:
+		elem, ok := h.typeIdent(v.Elem())
+		return "Ptr" + elem, ok
+	case *types.Slice:
+		elem, ok := h.typeIdent(v.Elem())
+		return "Slice" + elem, ok
+	case *types.Array:
+		elem, ok := h.typeIdent(v.Elem())
+		return fmt.Sprintf("Array%d%s", v.Len(), elem), ok
+	case *types.Map:
+		key, keyOk := h.typeIdent(v.Key())
+		elem, elemOk := h.typeIdent(v.Elem())
+		return "Map" + key + elem, keyOk && elemOk
+	case *types.Interface:
+		return "Any", v.Empty()
+	default:
+		return "", false
+	}
+}
+
+// chainableElem reports whether mutators may be chained into the elements of
+// type t of maps. Map elements are not addressable, only struct pointers can
+// be chained.
+func chainableElem(t types.Type) bool {
+	_, isPointer := t.Underlying().(*types.Pointer)
+	return isPointer
+}
+
+// isGeneric reports whether named is a generic type, either not instantiated
+// or instantiated with type parameters.
+func isGeneric(named *types.Named) bool {
+	return named.TypeParams().Len() > 0 && (named.TypeArgs().Len() == 0 || hasTypeParam(named))
+}
+
+// hasTypeParam reports whether t refers to type parameters.
+func hasTypeParam(t types.Type) bool {
+	switch v := t.(type) {
+	case *types.TypeParam:
+		return true
+	case *types.Pointer:
+		return hasTypeParam(v.Elem())
+	case *types.Slice:
+		return hasTypeParam(v.Elem())
+	case *types.Array:
+		return hasTypeParam(v.Elem())
+	case *types.Chan:
+		return hasTypeParam(v.Elem())
+	case *types.Map:
+		return hasTypeParam(v.Key()) || hasTypeParam(v.Elem())
+	case *types.Named:
+		for i := 0; i < v.TypeArgs().Len(); i++ {
+			if hasTypeParam(v.TypeArgs().At(i)) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// isContainer reports whether t is a slice or map mutated element-wise,
+// byte slices are mutated as a whole.
+func isContainer(t types.Type) bool {
+	switch t.(type) {
+	case *types.Slice:
+		return !isByteSlice(t)
+	case *types.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+func isByteSlice(t types.Type) bool {
+	slice, isSlice := t.(*types.Slice)
+	if !isSlice {
+		return false
+	}
+
+	elem, isBasic := slice.Elem().Underlying().(*types.Basic)
+	return isBasic && elem.Kind() == types.Byte
+}
+
+// exportedName upper-cases the first letter of name.
+func exportedName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"fmt"
+	"go/types"
+	"path"
+)
+
+// importSet tracks the packages imported by the generated file, assigning
+// unique names to packages sharing the same name.
+type importSet struct {
+	imports []importData
+	names   map[string]string // package path to name
+	paths   map[string]string // name to package path
+}
+
+// reservedNames are the identifiers declared by the templates, e.g. the
+// receiver m, which would shadow packages imported under the same name.
+var reservedNames = []string{
+	"m", "obj", "options", "option", "logger", "value", "key", "index",
+	"object", "prefix", "current", "currentValue", "ptr", "i", "item",
+	"isImpl", "recorder",
+}
+
+func newImportSet(paths ...string) *importSet {
+	s := &importSet{
+		names: make(map[string]string),
+		paths: make(map[string]string),
+	}
+
+	for _, name := range reservedNames {
+		s.paths[name] = ""
+	}
+
+	for _, importPath := range paths {
+		s.add(importPath, path.Base(importPath))
+	}
+
+	return s
+}
+
+// name returns the name under which pkg is imported, importing it if needed.
+func (s *importSet) name(pkg *types.Package) string {
+	if name, exists := s.names[pkg.Path()]; exists {
+		return name
+	}
+
+	return s.add(pkg.Path(), pkg.Name())
+}
+
+func (s *importSet) add(importPath, pkgName string) string {
+	name := pkgName
+	for i := 2; s.taken(name); i++ {
+		name = fmt.Sprintf("%s%d", pkgName, i)
+	}
+
+	s.names[importPath] = name
+	s.paths[name] = importPath
+
+	alias := ""
+	if name != pkgName {
+		alias = name
+	}
+	s.imports = append(s.imports, importData{Alias: alias, Path: importPath})
+
+	return name
+}
+
+// taken reports whether name is reserved or imports another package.
+func (s *importSet) taken(name string) bool {
+	_, taken := s.paths[name]
+	return taken
+}
+
+// used returns the imports whose names are in names.
+func (s *importSet) used(names map[string]bool) []importData {
+	var used []importData
+	for _, imp := range s.imports {
+		if names[s.names[imp.Path]] {
+			used = append(used, imp)
+		}
+	}
+
+	return used
+}
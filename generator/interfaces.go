@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"go/types"
+)
+
+// handleInterface generates the setter of an interface field, and the
+// navigation to the mutators of its implementations, e.g. PaymentAsCard
+// returning the mutator of the *Card held by the Payment field.
+func (h *handler) handleInterface(
+	owner mutatorData,
+	field fieldInfo,
+	fieldType types.Type,
+	prefix string,
+) []templateStep {
+	steps := h.handleOther(owner, field, fieldType)
+
+	if !field.options.Navigable() {
+		return steps
+	}
+
+	for _, impl := range h.implementations(fieldType) {
+		chained := h.chain(impl)
+		mutator := h.mutatorData(chained)
+
+		h.addPrefix(prefix, field)
+
+		steps = append(steps, templateStep{
+			template: &mutateInterfaceTemplate,
+			data: mutateFunctionData{
+				TypeName:      owner.TypeName,
+				Mutator:       owner.Mutator,
+				FieldName:     field.Name(),
+				FieldTypeName: h.typeName(impl),
+				FieldMutator:  mutator.Mutator,
+				ImplName:      mutator.Name,
+				Prefix:        prefix,
+			},
+		})
+	}
+
+	return steps
+}
+
+// implementations returns pointers to the struct types of the package of the
+// roots implementing the interface t, sorted by name, which are chained like
+// other struct types, see chainedStruct. Values held by an interface are not
+// addressable, so implementations held as values cannot be mutated.
+func (h *handler) implementations(t types.Type) []types.Type {
+	iface, isInterface := t.Underlying().(*types.Interface)
+	if !isInterface || iface.Empty() || hasTypeParam(t) {
+		return nil
+	}
+
+	var impls []types.Type
+
+	scope := h.pkg.Scope()
+	for _, name := range scope.Names() {
+		obj, isTypeName := scope.Lookup(name).(*types.TypeName)
+		if !isTypeName || obj.IsAlias() {
+			continue
+		}
+
+		named, isNamed := obj.Type().(*types.Named)
+		if !isNamed || named.TypeParams().Len() > 0 {
+			continue
+		}
+
+		// unexported structs, or structs without exported fields mutated
+		// from another package, get no mutator
+		if h.chainedStruct(named) == nil {
+			continue
+		}
+
+		if ptr := types.NewPointer(named); types.Implements(ptr, iface) {
+			impls = append(impls, ptr)
+		}
+	}
+
+	return impls
+}
@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+)
+
+// fieldComment holds the comments of a field declaration.
+type fieldComment struct {
+	doc     string
+	comment string
+}
+
+// fieldComments returns the comments of the struct fields declared in files,
+// by position of their name, or of their type name if embedded.
+func fieldComments(files []*ast.File) map[token.Pos]fieldComment {
+	comments := make(map[token.Pos]fieldComment)
+
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			structType, isStruct := node.(*ast.StructType)
+			if !isStruct {
+				return true
+			}
+
+			for _, field := range structType.Fields.List {
+				if field.Doc == nil && field.Comment == nil {
+					continue
+				}
+
+				comment := fieldComment{
+					doc:     strings.TrimSpace(field.Doc.Text()),
+					comment: strings.TrimSpace(field.Comment.Text()),
+				}
+
+				if len(field.Names) == 0 {
+					if ident := embeddedIdent(field.Type); ident != nil {
+						comments[ident.Pos()] = comment
+					}
+				}
+
+				for _, name := range field.Names {
+					comments[name.Pos()] = comment
+				}
+			}
+
+			return true
+		})
+	}
+
+	return comments
+}
+
+// embeddedIdent returns the type name of an embedded field, which is where
+// the type checker positions it, e.g. Page of *pkg.Page[T].
+func embeddedIdent(expr ast.Expr) *ast.Ident {
+	for {
+		switch e := expr.(type) {
+		case *ast.Ident:
+			return e
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.SelectorExpr:
+			expr = e.Sel
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		default:
+			return nil
+		}
+	}
+}
+
+// fieldMetadata returns the description of field given to templates.
+func (h *handler) fieldMetadata(field fieldInfo) fieldData {
+	comment := h.comments[field.Pos()]
+
+	return fieldData{
+		Name:      field.Name(),
+		Type:      h.typeName(field.Type()),
+		Kind:      typeKind(field.Type()),
+		Tag:       reflect.StructTag(field.tag),
+		Doc:       comment.doc,
+		Comment:   comment.comment,
+		Embedded:  field.Embedded(),
+		Readonly:  field.options.Readonly,
+		Immutable: field.options.Immutable,
+	}
+}
+
+// typeKind returns the kind of the underlying type of t.
+func typeKind(t types.Type) string {
+	if _, isTypeParam := t.(*types.TypeParam); isTypeParam {
+		return "typeparam"
+	}
+
+	switch t.Underlying().(type) {
+	case *types.Basic:
+		return "basic"
+	case *types.Pointer:
+		return "pointer"
+	case *types.Slice:
+		return "slice"
+	case *types.Array:
+		return "array"
+	case *types.Map:
+		return "map"
+	case *types.Chan:
+		return "chan"
+	case *types.Struct:
+		return "struct"
+	case *types.Signature:
+		return "func"
+	case *types.Interface:
+		return "interface"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// generatedComment starts the files generated by gomutate, whose methods are
+// replaced by the generated ones.
+const generatedComment = "// Code generated by gomutate"
+
+// checkMethods reports the methods of the generated source declared twice,
+// which may happen with custom names, and, if source is generated into pkg,
+// those already declared on the same types by the other files of pkg, e.g.
+// hand-written methods of a mutator.
+func checkMethods(pkg *packages.Package, source []byte, samePackage bool) error {
+	file, err := parser.ParseFile(token.NewFileSet(), "", source, 0)
+	if err != nil {
+		return fmt.Errorf("parsing generated code: %w", err)
+	}
+
+	declared := make(map[string]token.Position)
+	if samePackage {
+		for _, f := range pkg.Syntax {
+			if isGeneratedFile(f) {
+				continue
+			}
+
+			for name, pos := range methods(f) {
+				declared[name] = pkg.Fset.Position(pos)
+			}
+		}
+	}
+
+	generated := make(map[string]bool)
+	for _, decl := range file.Decls {
+		name := methodName(decl)
+		if name == "" {
+			continue
+		}
+
+		if generated[name] {
+			return fmt.Errorf("method %s would be generated twice, check the naming configuration", name)
+		}
+
+		if pos, exists := declared[name]; exists {
+			return fmt.Errorf("method %s would be generated, but is already declared at %s", name, pos)
+		}
+
+		generated[name] = true
+	}
+
+	return nil
+}
+
+// methods returns the positions of the methods declared in file, by name.
+func methods(file *ast.File) map[string]token.Pos {
+	positions := make(map[string]token.Pos)
+	for _, decl := range file.Decls {
+		if name := methodName(decl); name != "" {
+			positions[name] = decl.Pos()
+		}
+	}
+
+	return positions
+}
+
+// methodName returns the name of the method declared by decl, qualified by
+// its receiver type name, e.g. MutatorAcme.SetName, or an empty string if
+// decl does not declare a method.
+func methodName(decl ast.Decl) string {
+	funcDecl, isFunc := decl.(*ast.FuncDecl)
+	if !isFunc || funcDecl.Recv == nil || len(funcDecl.Recv.List) != 1 {
+		return ""
+	}
+
+	recv := embeddedIdent(funcDecl.Recv.List[0].Type)
+	if recv == nil {
+		return ""
+	}
+
+	return recv.Name + "." + funcDecl.Name.Name
+}
+
+func isGeneratedFile(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+
+		for _, comment := range group.List {
+			if strings.HasPrefix(comment.Text, generatedComment) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
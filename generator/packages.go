@@ -0,0 +1,202 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// GenerateDirective annotates the struct types to generate mutators for in
+// package pattern mode, e.g.
+//
+//	//gomutate:generate
+//	type Acme struct {
+const GenerateDirective = "//gomutate:generate"
+
+// OutputSuffix names the generated files, after the package in package
+// pattern mode, or after the first type when no files are given. Mutators of
+// a package are written to a single file in package pattern mode, since
+// struct types reachable from several annotated types share their mutators.
+const OutputSuffix = "_mutator.go"
+
+// generatePackages generates the mutators of the annotated struct types of
+// the packages matching the patterns of the options, and of the types listed
+// by their configuration.
+func (g *generation) generatePackages(ctx context.Context) error {
+	loadCfg := loadConfig(ctx, g.opts.Tags)
+	loadCfg.Dir = g.opts.Dir
+
+	pkgs, err := packages.Load(loadCfg, g.opts.Patterns...)
+	if err != nil {
+		return err
+	}
+
+	// the configuration file of the options replaces the one of the package
+	// in its directory only, the other packages have their own types and
+	// output
+	configFile := g.path(g.opts.Config)
+	if configFile != "" {
+		if configFile, err = filepath.Abs(configFile); err != nil {
+			return err
+		}
+	}
+
+	configured := false
+
+	for _, pkg := range pkgs {
+		if len(pkg.GoFiles) == 0 {
+			continue
+		}
+
+		dir := filepath.Dir(pkg.GoFiles[0])
+
+		filename := ""
+		if configFile != "" && filepath.Dir(configFile) == dir {
+			filename = configFile
+			configured = true
+		}
+
+		cfg, err := g.config(dir, filename)
+		if err != nil {
+			return err
+		}
+
+		roots, err := annotatedTypes(pkg)
+		if err != nil {
+			return err
+		}
+
+		for _, typeName := range cfg.Types.names {
+			named, isNamed := lookupType(pkg, typeName)
+			if !isNamed {
+				return fmt.Errorf("%s: type %s not found", pkg.PkgPath, typeName)
+			}
+
+			if _, isStruct := named.Underlying().(*types.Struct); !isStruct {
+				return fmt.Errorf("%s: type %s is not a struct", pkg.PkgPath, typeName)
+			}
+
+			if !containsType(roots, named) {
+				roots = append(roots, named)
+			}
+		}
+
+		if len(roots) == 0 {
+			continue
+		}
+
+		// errors in files declaring no annotated types, such as a stale
+		// output file, must not prevent generation
+		if err := g.diagnoseErrors(pkg, rootFiles(pkg, roots)); err != nil {
+			return err
+		}
+
+		output := cfg.output(dir)
+		if output == "" {
+			if cfg.OutputPackage != "" {
+				return fmt.Errorf("%s: the output of package %s must be set", pkg.PkgPath, cfg.OutputPackage)
+			}
+
+			output = filepath.Join(dir, pkg.Name+OutputSuffix)
+		}
+
+		target, err := outputPackage(pkg, cfg.OutputPackage, output)
+		if err != nil {
+			return fmt.Errorf("%s: %w", pkg.PkgPath, err)
+		}
+
+		if err := checkRoots(pkg, target, roots, cfg); err != nil {
+			return fmt.Errorf("%s: %w", pkg.PkgPath, err)
+		}
+
+		source, err := g.generate(pkg, target, roots, cfg)
+		if err != nil {
+			return fmt.Errorf("%s: %w", pkg.PkgPath, err)
+		}
+
+		g.files[output] = source
+	}
+
+	if configFile != "" && !configured {
+		return fmt.Errorf("%w: configuration file %s is not in the directory of a package matched by the patterns", ErrInvalidOptions, g.opts.Config)
+	}
+
+	return nil
+}
+
+// annotatedTypes returns the struct types of pkg annotated with the generate
+// directive, in declaration order.
+func annotatedTypes(pkg *packages.Package) ([]*types.Named, error) {
+	var roots []*types.Named
+
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			genDecl, isGenDecl := decl.(*ast.GenDecl)
+			if !isGenDecl || genDecl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+
+				doc := typeSpec.Doc
+				if doc == nil && len(genDecl.Specs) == 1 {
+					doc = genDecl.Doc
+				}
+
+				if !hasGenerateDirective(doc) {
+					continue
+				}
+
+				obj, isTypeName := pkg.TypesInfo.Defs[typeSpec.Name].(*types.TypeName)
+				if !isTypeName {
+					continue
+				}
+
+				named, isNamed := obj.Type().(*types.Named)
+				if !isNamed {
+					return nil, fmt.Errorf("%s: type %s is not a struct", pkg.Fset.Position(typeSpec.Pos()), typeSpec.Name.Name)
+				}
+
+				if _, isStruct := named.Underlying().(*types.Struct); !isStruct {
+					return nil, fmt.Errorf("%s: type %s is not a struct", pkg.Fset.Position(typeSpec.Pos()), typeSpec.Name.Name)
+				}
+
+				roots = append(roots, named)
+			}
+		}
+	}
+
+	return roots, nil
+}
+
+func hasGenerateDirective(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+
+	for _, comment := range doc.List {
+		text := strings.TrimRight(comment.Text, " \t")
+		if text == GenerateDirective || strings.HasPrefix(text, GenerateDirective+" ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsType(list []*types.Named, t *types.Named) bool {
+	for _, item := range list {
+		if types.Identical(item, t) {
+			return true
+		}
+	}
+
+	return false
+}
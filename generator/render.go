@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	gofmt "go/format"
+	"go/parser"
+	"go/token"
+	"text/template"
+)
+
+// render writes the generated code in body after the header of the generated
+// file, which imports only the packages referred to by the generated code,
+// and formats the result unless format is false.
+func render(packageName string, imports *importSet, body []byte, format bool, templates templateSet) ([]byte, error) {
+	// package names are left unresolved by the parser, unlike local identifiers
+	// which may shadow them, e.g. a changes parameter of type changes.Logger
+	file, err := parser.ParseFile(token.NewFileSet(), "", append([]byte("package "+packageName+"\n"), body...), 0)
+	if err != nil {
+		return nil, fmt.Errorf("parsing generated code: %w", err)
+	}
+
+	unresolved := make(map[string]bool, len(file.Unresolved))
+	for _, ident := range file.Unresolved {
+		unresolved[ident.Name] = true
+	}
+
+	var source bytes.Buffer
+	err = executeSteps(&source, []templateStep{
+		{
+			template: &headerTemplate,
+			data: headerData{
+				PackageName: packageName,
+				Imports:     imports.used(unresolved),
+			},
+		},
+	}, templates)
+	if err != nil {
+		return nil, err
+	}
+	source.Write(body)
+
+	if !format {
+		return source.Bytes(), nil
+	}
+
+	formatted, err := gofmt.Source(source.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+
+	return formatted, nil
+}
+
+func executeSteps(buf *bytes.Buffer, steps []templateStep, templates templateSet) error {
+	for i, step := range steps {
+		tmpl, err := template.New(fmt.Sprintf("template%d", i)).Funcs(templateFuncs).Parse(templates.text(step.template))
+		if err != nil {
+			return err
+		}
+
+		err = tmpl.Execute(buf, step.data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
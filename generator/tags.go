@@ -0,0 +1,45 @@
+package generator
+
+import (
+	"go/types"
+	"reflect"
+
+	"github.com/pdcalado/gomutate/internal/tags"
+)
+
+// fieldOptions returns the options of the field of named at index, given by
+// the configuration if set, or by its struct tag otherwise. The configuration
+// qualifies the types of other packages than the one of the roots.
+func (h *handler) fieldOptions(named *types.Named, index int) (tags.Options, error) {
+	structType := named.Underlying().(*types.Struct)
+
+	key := named.Obj().Name() + "." + structType.Field(index).Name()
+	if pkg := named.Obj().Pkg(); pkg != h.pkg {
+		key = pkg.Name() + "." + key
+	}
+
+	if value, found := h.fieldTags[key]; found {
+		return tags.Parse(value)
+	}
+
+	return tags.Lookup(reflect.StructTag(structType.Tag(index)))
+}
+
+// fieldInfo is a struct field along with its options.
+type fieldInfo struct {
+	*types.Var
+	options tags.Options
+	tag     string
+	index   int
+}
+
+// method returns the name of the field in the names of its methods, which is
+// capitalized for unexported fields, e.g. SetInternalID for internalID.
+func (f fieldInfo) method() string {
+	return exportedName(f.Name())
+}
+
+// displayName returns the name of the field in changes.
+func (f fieldInfo) displayName() string {
+	return f.options.DisplayName(f.Name())
+}
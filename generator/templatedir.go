@@ -0,0 +1,122 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// TemplateSuffix is the extension of the files replacing built-in templates.
+const TemplateSuffix = ".tmpl"
+
+// builtinTemplates maps the names of the templates, which the files of
+// Options.Templates are named after, e.g. mutateField.tmpl, to their variables.
+var builtinTemplates = map[string]*string{
+	"header":                   &headerTemplate,
+	"fieldNames":               &fieldNamesTemplate,
+	"mainMutator":              &mainMutatorTemplate,
+	"subMutator":               &subMutatorTemplate,
+	"mutateField":              &mutateFieldTemplate,
+	"enumNames":                &enumNamesTemplate,
+	"mutateEnum":               &mutateEnumTemplate,
+	"mutateByteSlice":          &mutateByteSliceTemplate,
+	"mapOrSliceSet":            &mapOrSliceSetTemplate,
+	"mapInsert":                &mapInsertTemplate,
+	"sliceAppend":              &sliceAppendTemplate,
+	"promotedSetter":           &promotedSetterTemplate,
+	"mutateSetObj":             &mutateSetObjTemplate,
+	"mutateSetPtr":             &mutateSetPtrTemplate,
+	"mutatePtr":                &mutatePtrTemplate,
+	"mutateSliceElement":       &mutateSliceElementTemplate,
+	"mutateObj":                &mutateObjTemplate,
+	"mutateInterface":          &mutateInterfaceTemplate,
+	"mutateNestedSliceElement": &mutateNestedSliceElementTemplate,
+	"mutateNestedMapElement":   &mutateNestedMapElementTemplate,
+	"sliceMutator":             &sliceMutatorTemplate,
+	"mapMutator":               &mapMutatorTemplate,
+	"containerStructElement":   &containerStructElementTemplate,
+	"containerNestedElement":   &containerNestedElementTemplate,
+	"mutateMapElement":         &mutateMapElementTemplate,
+	"mutatorInterface":         &mutatorInterfaceTemplate,
+	"mutatorFake":              &mutatorFakeTemplate,
+}
+
+// templateFuncs are the functions available to templates, in addition to the
+// predefined ones of text/template.
+var templateFuncs = template.FuncMap{
+	"split":     strings.Split,
+	"join":      strings.Join,
+	"lower":     strings.ToLower,
+	"upper":     strings.ToUpper,
+	"hasPrefix": strings.HasPrefix,
+	"hasSuffix": strings.HasSuffix,
+	"contains":  strings.Contains,
+}
+
+// templateSet maps built-in templates to the templates replacing them.
+type templateSet map[*string]string
+
+// text returns the template replacing builtin, or builtin itself.
+func (s templateSet) text(builtin *string) string {
+	if text, found := s[builtin]; found {
+		return text
+	}
+
+	return *builtin
+}
+
+// loadTemplates returns the templates of the files of dir named after the
+// built-in templates they replace, or no templates if dir is empty. Other
+// files are ignored, but files with the template suffix must be named after a
+// built-in template.
+func loadTemplates(dir string) (templateSet, error) {
+	templates := make(templateSet)
+	if dir == "" {
+		return templates, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), TemplateSuffix) {
+			continue
+		}
+
+		filename := filepath.Join(dir, entry.Name())
+
+		builtin, found := builtinTemplates[strings.TrimSuffix(entry.Name(), TemplateSuffix)]
+		if !found {
+			return nil, fmt.Errorf("unknown template %s, expected one of %s", filename, strings.Join(templateNames(), ", "))
+		}
+
+		text, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := template.New(entry.Name()).Funcs(templateFuncs).Parse(string(text)); err != nil {
+			return nil, fmt.Errorf("invalid template %s: %w", filename, err)
+		}
+
+		templates[builtin] = string(text)
+	}
+
+	return templates, nil
+}
+
+func templateNames() []string {
+	names := make([]string, 0, len(builtinTemplates))
+	for name := range builtinTemplates {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
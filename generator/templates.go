@@ -0,0 +1,624 @@
+package generator
+
+import "reflect"
+
+var (
+	headerTemplate = `// Code generated by gomutate; DO NOT EDIT.
+package {{.PackageName}}
+
+import (
+	{{range .Imports}}{{with .Alias}}{{.}} {{end}}"{{.Path}}"
+	{{end}}
+)
+`
+
+	fieldNamesTemplate string = `
+{{with .}}
+const ({{range .}}
+	{{.ConstName}} changes.FieldName = {{printf "%q" .ConstValue}}{{end}}
+){{end}}
+`
+
+	mainMutatorTemplate = `
+// {{.MutatorName}} mutates the {{.TypeName}} object.
+type {{.MutatorName}}{{.TypeParams}} struct {
+	inner   *{{.TypeName}}
+	changes changes.Logger
+}
+
+// {{.Constructor}} creates a new mutator for the {{.TypeName}} object.
+func {{.Constructor}}{{.TypeParams}}(
+	obj *{{.TypeName}},
+	options ...func(*{{.Mutator}}),
+) *{{.Mutator}} {
+	m := &{{.Mutator}}{
+		inner:   obj,
+		changes: changes.NewDefaultLogger(changes.PrefixEmpty),
+	}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
+}
+
+// WithChangeLogger{{.Name}} sets the change logger for the {{.TypeName}} mutator.
+func WithChangeLogger{{.Name}}{{.TypeParams}}(logger changes.Logger) func(*{{.Mutator}}) {
+	return func(m *{{.Mutator}}) {
+		m.changes = logger
+	}
+}
+
+// FormatChanges returns the changes that were made to the object as strings
+func (m *{{.Mutator}}) FormatChanges() []string {
+	return m.changes.ToString()
+}
+`
+
+	subMutatorTemplate = `
+type {{.MutatorName}}{{.TypeParams}} struct {
+	inner   *{{.TypeName}}
+	changes changes.Logger
+}
+
+func {{.Constructor}}{{.TypeParams}}(obj *{{.TypeName}}, changes changes.Logger) *{{.Mutator}} {
+	return &{{.Mutator}}{
+		inner:   obj,
+		changes: changes,
+	}
+}
+`
+
+	mutateFieldTemplate = `
+// {{.Names.Set}} mutates the {{.FieldName}} of the {{.TypeName}} object
+func (m *{{.Mutator}}) {{.Names.Set}}(value {{.FieldTypeName}}) bool {
+{{if .Immutable}}	if !changes.IsZero(&m.inner.{{.FieldName}}) {
+		return false
+	}
+
+{{end}}	return changes.Set(m.changes, {{printf "%q" .DisplayName}}, &m.inner.{{.FieldName}}, value, {{.FieldEqual}})
+}
+`
+
+	enumNamesTemplate = `
+// {{.Names}} maps the declared {{.TypeName}} constants to their names.
+var {{.Names}} = map[{{.TypeName}}]string{
+{{- range .Constants}}
+	{{.Name}}: {{printf "%q" .DisplayName}},
+{{- end}}
+}
+`
+
+	mutateEnumTemplate = `
+// {{.Names.Set}} mutates the {{.FieldName}} of the {{.TypeName}} object,
+// values other than the declared {{.FieldTypeName}} constants are rejected.
+func (m *{{.Mutator}}) {{.Names.Set}}(value {{.FieldTypeName}}) bool {
+{{if .Immutable}}	if !changes.IsZero(&m.inner.{{.FieldName}}) {
+		return false
+	}
+
+{{end}}	return changes.SetEnum(m.changes, {{printf "%q" .DisplayName}}, &m.inner.{{.FieldName}}, value, {{.EnumNames}})
+}
+`
+
+	mutateByteSliceTemplate = `
+// {{.Names.Set}} mutates the {{.FieldName}} of the {{.TypeName}} object
+func (m *{{.Mutator}}) {{.Names.Set}}(value {{.FieldTypeName}}) bool {
+{{if .Immutable}}	if !changes.IsZero(&m.inner.{{.FieldName}}) {
+		return false
+	}
+
+{{end}}	return changes.SetBytes(m.changes, {{printf "%q" .DisplayName}}, &m.inner.{{.FieldName}}, value)
+}
+`
+
+	mapOrSliceSetTemplate = `
+// {{.Names.Set}} sets {{.FieldName}} of the {{.TypeName}} object
+func (m *{{.Mutator}}) {{.Names.Set}}(value {{.FieldTypeName}}) bool {
+{{if .Immutable}}	if !changes.IsZero(&m.inner.{{.FieldName}}) {
+		return false
+	}
+
+{{end}}	return changes.{{if eq .Field.Kind "map"}}SetMap{{else}}SetSlice{{end}}(m.changes, {{printf "%q" .DisplayName}}, &m.inner.{{.FieldName}}, value)
+}
+`
+
+	mapInsertTemplate = `
+// {{.Names.Insert}} inserts a {{.FieldName}} map element of the {{.TypeName}} object.
+func (m *{{.Mutator}}) {{.Names.Insert}}(
+	key {{.FieldKeyTypeName}},
+	value {{.FieldElemTypeName}},
+) bool {
+	return changes.Insert(m.changes, {{printf "%q" .DisplayName}}, &m.inner.{{.FieldName}}, key, value, func(currentValue, value {{.FieldElemTypeName}}) bool {
+		return {{.FieldElemEqual}}
+	})
+}
+
+// {{.Names.Remove}} removes a {{.FieldName}} map element of the {{.TypeName}} object.
+func (m *{{.Mutator}}) {{.Names.Remove}}(key {{.FieldKeyTypeName}}) bool {
+	return changes.RemoveKey(m.changes, {{printf "%q" .DisplayName}}, &m.inner.{{.FieldName}}, key)
+}
+`
+
+	sliceAppendTemplate = `
+// {{.Names.Append}} appends a {{.FieldName}} element of the {{.TypeName}} object.
+func (m *{{.Mutator}}) {{.Names.Append}}(value ...{{.FieldElemTypeName}}) {
+	changes.Append(m.changes, {{printf "%q" .DisplayName}}, &m.inner.{{.FieldName}}, value...)
+}
+
+// {{.Names.Remove}} removes a {{.FieldName}} element of the {{.TypeName}} object.
+func (m *{{.Mutator}}) {{.Names.Remove}}(index int) {
+	changes.RemoveIndex(m.changes, {{printf "%q" .DisplayName}}, &m.inner.{{.FieldName}}, index)
+}
+`
+
+	promotedSetterTemplate = `
+// {{.Names.Set}} mutates the {{.FieldName}} of the {{.TypeName}} object, promoted from {{.EmbeddedName}}
+func (m *{{.Mutator}}) {{.Names.Set}}(value {{.FieldTypeName}}) bool {
+	return m.{{.EmbeddedMethod}}().{{.EmbeddedSet}}(value)
+}
+`
+
+	mutateSetObjTemplate = `
+// {{.Names.Set}} sets {{.FieldName}} of the {{.TypeName}} object
+func (m *{{.Mutator}}) {{.Names.Set}}(value *{{.FieldTypeName}}) bool {
+{{if .Immutable}}	if !changes.IsZero(&m.inner.{{.FieldName}}) {
+		return false
+	}
+
+{{end}}	return changes.SetObject(m.changes, {{printf "%q" .DisplayName}}, &m.inner.{{.FieldName}}, value)
+}
+`
+
+	mutateSetPtrTemplate = `
+// {{.Names.Set}} sets {{.FieldName}} of the {{.TypeName}} object
+func (m *{{.Mutator}}) {{.Names.Set}}(value {{.FieldTypeName}}) bool {
+{{if .Immutable}}	if !changes.IsZero(&m.inner.{{.FieldName}}) {
+		return false
+	}
+
+{{end}}	return changes.SetPointer(m.changes, {{printf "%q" .DisplayName}}, &m.inner.{{.FieldName}}, value, {{.FieldEqual}})
+}
+`
+
+	mutatePtrTemplate = `
+// {{.Method}} returns a mutator for {{.FieldName}} of the {{.TypeName}} object.
+// If the field is nil, it will be initialized to a new {{.FieldTypeName}} object.
+func (m *{{.Mutator}}) {{.Method}}() *{{.FieldMutator}} {
+
+	if m.inner.{{.FieldName}} == nil {
+		m.inner.{{.FieldName}} = &{{.FieldTypeName}}{}
+	}
+
+	prefix := changes.NewPrefix(MutationPrefix{{.Prefix}})
+
+	return &{{.FieldMutator}}{
+		inner:   m.inner.{{.FieldName}},
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+`
+
+	mutateSliceElementTemplate = `
+// {{.Names.At}} returns a mutator for {{.FieldName}} element at index of the {{.TypeName}} object.
+func (m *{{.Mutator}}) {{.Names.At}}(index int) *{{.FieldMutator}} {
+	object := {{if .FieldTypeIsPointer}}{{else}}&{{end}}m.inner.{{.FieldName}}[index]
+
+	prefix := changes.NewPrefixWithKey(MutationPrefix{{.Prefix}}, changes.IntoKey(object))
+
+	return &{{.FieldMutator}}{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+{{if .FieldTypeIsPointer}}
+// {{.Names.ByPtr}} returns a mutator for {{.FieldName}} element given by a pointer of type {{.TypeName}}.
+func (m *{{.Mutator}}) {{.Names.ByPtr}}(ptr {{.FieldElemTypeName}}) *{{.FieldMutator}} {
+	for i, item := range m.inner.{{.FieldName}} {
+		if item == ptr {
+			return m.{{.Names.At}}(i)
+		}
+	}
+	return nil
+}{{end}}
+`
+
+	mutateObjTemplate = `
+// {{.Method}} returns a mutator for {{.FieldName}} of the {{.TypeName}} object.
+func (m *{{.Mutator}}) {{.Method}}() *{{.FieldMutator}} {
+	prefix := changes.NewPrefix(MutationPrefix{{.Prefix}})
+
+	return &{{.FieldMutator}}{
+		inner:   &m.inner.{{.FieldName}},
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+`
+
+	mutateInterfaceTemplate = `
+// {{.Method}}As{{.ImplName}} returns a mutator for {{.FieldName}} of the {{.TypeName}} object
+// if it holds a {{.FieldTypeName}}, or nil otherwise.
+func (m *{{.Mutator}}) {{.Method}}As{{.ImplName}}() *{{.FieldMutator}} {
+	object, isImpl := m.inner.{{.FieldName}}.({{.FieldTypeName}})
+	if !isImpl || object == nil {
+		return nil
+	}
+
+	prefix := changes.NewPrefixWithKey(MutationPrefix{{.Prefix}}, {{printf "%q" .ImplName}})
+
+	return &{{.FieldMutator}}{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+`
+
+	mutateNestedSliceElementTemplate = `
+// {{.Names.At}} returns a mutator for {{.FieldName}} element at index of the {{.TypeName}} object.
+func (m *{{.Mutator}}) {{.Names.At}}(index int) *{{.FieldMutator}} {
+	prefix := changes.NewPrefixWithKey(MutationPrefix{{.Prefix}}, changes.IntoKey(index))
+
+	return &{{.FieldMutator}}{
+		get: func() {{.FieldElemTypeName}} {
+			return m.inner.{{.FieldName}}[index]
+		},
+		set: func(value {{.FieldElemTypeName}}) {
+			m.inner.{{.FieldName}}[index] = value
+		},
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+`
+
+	mutateNestedMapElementTemplate = `
+// {{.Names.WithKey}} returns a mutator for {{.FieldName}} map element of the {{.TypeName}} object with given key.
+func (m *{{.Mutator}}) {{.Names.WithKey}}(key {{.FieldKeyTypeName}}) *{{.FieldMutator}} {
+	prefix := changes.NewPrefixWithKey(MutationPrefix{{.Prefix}}, changes.IntoKey(key))
+
+	return &{{.FieldMutator}}{
+		get: func() {{.FieldElemTypeName}} {
+			return m.inner.{{.FieldName}}[key]
+		},
+		set: func(value {{.FieldElemTypeName}}) {
+			if m.inner.{{.FieldName}} == nil {
+				m.inner.{{.FieldName}} = make({{.FieldTypeName}})
+			}
+			m.inner.{{.FieldName}}[key] = value
+		},
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+`
+
+	sliceMutatorTemplate = `
+// {{.Mutator}} mutates a {{.TypeName}} element of a slice or map.
+type {{.Mutator}} struct {
+	get     func() {{.TypeName}}
+	set     func({{.TypeName}})
+	changes changes.Logger
+}
+
+// {{.Names.Set}} sets the {{.TypeName}} element.
+func (m *{{.Mutator}}) {{.Names.Set}}(value {{.TypeName}}) bool {
+	current := m.get()
+	if !changes.SetSlice(m.changes, "", &current, value) {
+		return false
+	}
+
+	m.set(current)
+
+	return true
+}
+
+// {{.Names.Append}} appends elements to the {{.TypeName}} element.
+func (m *{{.Mutator}}) {{.Names.Append}}(value ...{{.ElemTypeName}}) {
+	current := m.get()
+	changes.Append(m.changes, "", &current, value...)
+	m.set(current)
+}
+
+// {{.Names.Remove}} removes the element at index of the {{.TypeName}} element.
+func (m *{{.Mutator}}) {{.Names.Remove}}(index int) {
+	current := m.get()
+	changes.RemoveIndex(m.changes, "", &current, index)
+	m.set(current)
+}
+`
+
+	mapMutatorTemplate = `
+// {{.Mutator}} mutates a {{.TypeName}} element of a slice or map.
+type {{.Mutator}} struct {
+	get     func() {{.TypeName}}
+	set     func({{.TypeName}})
+	changes changes.Logger
+}
+
+// {{.Names.Set}} sets the {{.TypeName}} element.
+func (m *{{.Mutator}}) {{.Names.Set}}(value {{.TypeName}}) bool {
+	current := m.get()
+	if !changes.SetMap(m.changes, "", &current, value) {
+		return false
+	}
+
+	m.set(current)
+
+	return true
+}
+
+// {{.Names.Insert}} inserts an element into the {{.TypeName}} element.
+func (m *{{.Mutator}}) {{.Names.Insert}}(key {{.KeyTypeName}}, value {{.ElemTypeName}}) bool {
+	current := m.get()
+	if !changes.Insert(m.changes, "", &current, key, value, func(currentValue, value {{.ElemTypeName}}) bool {
+		return {{.ElemEqual}}
+	}) {
+		return false
+	}
+
+	m.set(current)
+
+	return true
+}
+
+// {{.Names.Remove}} removes an element from the {{.TypeName}} element.
+func (m *{{.Mutator}}) {{.Names.Remove}}(key {{.KeyTypeName}}) bool {
+	current := m.get()
+	return changes.RemoveKey(m.changes, "", &current, key)
+}
+`
+
+	containerStructElementTemplate = `
+// {{.Method}} returns a mutator for the element of the {{.TypeName}} element with given {{.KeyName}}.
+func (m *{{.Mutator}}) {{.Method}}({{.KeyName}} {{.KeyTypeName}}) *{{.ElemMutator}} {
+	object := {{if .ElemIsPointer}}{{else}}&{{end}}m.get()[{{.KeyName}}]
+
+	prefix := changes.NewPrefixWithKey(changes.FieldNameEmpty, changes.IntoKey({{.KeyName}}))
+
+	return &{{.ElemMutator}}{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+`
+
+	containerNestedElementTemplate = `
+// {{.Method}} returns a mutator for the element of the {{.TypeName}} element with given {{.KeyName}}.
+func (m *{{.Mutator}}) {{.Method}}({{.KeyName}} {{.KeyTypeName}}) *{{.ElemMutator}} {
+	prefix := changes.NewPrefixWithKey(changes.FieldNameEmpty, changes.IntoKey({{.KeyName}}))
+
+	return &{{.ElemMutator}}{
+		get: func() {{.ElemTypeName}} {
+			return m.get()[{{.KeyName}}]
+		},
+		set: func(value {{.ElemTypeName}}) {
+			{{- if .IsMap}}
+			current := m.get()
+			if current == nil {
+				current = make({{.TypeName}})
+				m.set(current)
+			}
+			current[{{.KeyName}}] = value
+			{{- else}}
+			m.get()[{{.KeyName}}] = value
+			{{- end}}
+		},
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+`
+
+	mutateMapElementTemplate = `
+// {{.Names.WithKey}} returns a mutator for {{.FieldName}} map element {{.TypeName}} object with given key.
+func (m *{{.Mutator}}) {{.Names.WithKey}}(key {{.FieldKeyTypeName}}) *{{.FieldMutator}} {
+	object := m.inner.{{.FieldName}}[key]
+
+	prefix := changes.NewPrefixWithKey(MutationPrefix{{.Prefix}}, changes.IntoKey(object))
+
+	return &{{.FieldMutator}}{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+`
+
+	mutatorInterfaceTemplate = `
+// {{.Interface}} is implemented by {{.Mutator}}, and by {{.Fake}} in tests.
+type {{.Interface}}{{.TypeParams}} interface {
+{{- range .Methods}}
+	{{.Name}}({{.Params}}) {{.Results}}
+{{- end}}
+}
+{{if not .TypeParams}}
+var _ {{.Interface}} = (*{{.Mutator}})(nil)
+{{end}}`
+
+	mutatorFakeTemplate = `
+// {{.Fake}} implements {{.Interface}} by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type {{.Fake}}{{.TypeParams}} struct {
+	recorder *changes.Recorder
+	path     string
+}
+
+// New{{.Fake}} creates a {{.Fake}} recording calls into recorder.
+func New{{.Fake}}{{.TypeParams}}(recorder *changes.Recorder) *{{.Fake}}{{.TypeArgs}} {
+	return &{{.Fake}}{{.TypeArgs}}{
+		recorder: recorder,
+	}
+}
+{{range .Methods}}
+// {{.Name}} {{.Doc}}.
+func (m *{{$.Fake}}{{$.TypeArgs}}) {{.Name}}({{.Params}}) {{.Results}} {
+{{- if .Record}}
+	m.recorder.Record(m.path+{{printf "%q" .Name}}{{range .Args}}, {{.}}{{end}})
+{{- end}}
+{{- with .Return}}
+	return {{.}}
+{{- end}}
+}
+{{end}}`
+)
+
+type templateStep struct {
+	// template is the built-in template, which may be replaced, see templateSet.
+	template *string
+	data     interface{}
+}
+
+type headerData struct {
+	PackageName string
+	Imports     []importData
+}
+
+type importData struct {
+	Alias string
+	Path  string
+}
+
+type mutatorData struct {
+	// TypeName is the mutated type as written in the generated package.
+	TypeName string
+	// Name identifies the mutated type in generated identifiers.
+	Name string
+	// Mutator is the mutator type, instantiated with its type parameters if generic.
+	Mutator string
+	// MutatorName is the name of the mutator type, without type parameters.
+	MutatorName string
+	// TypeParams declares the type parameters of generic mutators, e.g. [T any].
+	TypeParams  string
+	Constructor string
+}
+
+type mutateFunctionData struct {
+	TypeName  string
+	Mutator   string
+	FieldName string
+	// DisplayName is the name of the field in changes.
+	DisplayName      string
+	FieldKeyTypeName string
+	FieldTypeName    string
+	// FieldEqual reports whether the current and new values of the field are equal.
+	FieldEqual        string
+	FieldElemTypeName string
+	// FieldElemEqual reports whether the current and new values of an element are equal.
+	FieldElemEqual     string
+	FieldTypeIsPointer bool
+	FieldMutator       string
+	EmbeddedName       string
+	// Method and EmbeddedMethod are FieldName and EmbeddedName in method names.
+	Method         string
+	EmbeddedMethod string
+	// EmbeddedSet is the name of the setter of the mutator of the embedded
+	// field promoted to the mutator of the type, which may be named otherwise.
+	EmbeddedSet string
+	// Names are the names of the methods generated for the field.
+	Names  methodNames
+	Prefix string
+	// ImplName identifies the implementation held by an interface field.
+	ImplName string
+	// EnumNames is the map from the declared constants of an enum field type to their names.
+	EnumNames string
+	// Immutable fields may only be set while they have their zero value.
+	Immutable bool
+	// Field describes the declaration of the field, for user templates.
+	Field fieldData
+}
+
+// fieldData describes the declaration of a field.
+type fieldData struct {
+	Name string
+	// Type is the field type as written in the generated package.
+	Type string
+	// Kind is the kind of the underlying field type: basic, pointer, slice,
+	// array, map, chan, struct, func, interface or typeparam.
+	Kind string
+	// Tag is the struct tag of the field, e.g. {{.Field.Tag.Get "json"}}.
+	Tag reflect.StructTag
+	// Doc and Comment are the doc and line comments of the field, without
+	// comment markers. They are only known for fields of the generated package.
+	Doc       string
+	Comment   string
+	Embedded  bool
+	Readonly  bool
+	Immutable bool
+}
+
+// methodNames are the names of the methods generated for a field.
+type methodNames struct {
+	Set     string
+	At      string
+	ByPtr   string
+	WithKey string
+	Append  string
+	Insert  string
+	Remove  string
+}
+
+// containerData describes the mutator of a slice or map nested in another slice or map.
+type containerData struct {
+	TypeName     string
+	Mutator      string
+	KeyTypeName  string
+	ElemTypeName string
+	// ElemEqual reports whether the current and new values of an element are equal.
+	ElemEqual string
+	Names     methodNames
+}
+
+// containerElementData describes the navigation from a nested slice or map to its elements.
+type containerElementData struct {
+	TypeName      string
+	Mutator       string
+	Method        string
+	KeyName       string
+	KeyTypeName   string
+	ElemTypeName  string
+	ElemMutator   string
+	ElemIsPointer bool
+	IsMap         bool
+}
+
+// enumData describes the names of the constants declared with an enum type.
+type enumData struct {
+	Names     string
+	TypeName  string
+	Constants []enumConstantData
+}
+
+type enumConstantData struct {
+	// Name is the constant as written in the generated package.
+	Name string
+	// DisplayName is the name of the constant in changes.
+	DisplayName string
+}
+
+// doubleData describes the interface and the recording fake of a mutator.
+type doubleData struct {
+	Mutator   string
+	Interface string
+	Fake      string
+	// TypeParams declares the type parameters of generic mutators, e.g. [T any],
+	// and TypeArgs instantiates them, e.g. [T].
+	TypeParams string
+	TypeArgs   string
+	Methods    []doubleMethodData
+}
+
+type doubleMethodData struct {
+	Name    string
+	Params  string
+	Results string
+	// Doc describes what the fake does when the method is called.
+	Doc string
+	// Record is set if the fake records the calls, with Args.
+	Record bool
+	Args   []string
+	// Return is the value returned by the fake, if any.
+	Return string
+}
+
+type prefixData struct {
+	ConstName  string
+	ConstValue string
+}
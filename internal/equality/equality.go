@@ -0,0 +1,86 @@
+// Package equality holds the rules comparing the current and new values of
+// fields, applied by the generated mutators through the expressions generated
+// for them, and by the runtime mutators through Equal.
+//
+// Types with one of the Methods, like time.Time or *big.Int, are compared
+// with it, so that equal values with different representations are not
+// reported as changes. Other comparable types are compared with ==, and the
+// remaining ones with reflect.DeepEqual.
+package equality
+
+import "reflect"
+
+// Method is a method of a type T comparing a value with another value of
+// type T, declared as Name(T) Result.
+type Method struct {
+	Name string
+	// Result is the name of the predeclared result type, e.g. bool.
+	Result string
+	// Format formats the expression calling the method of its first operand
+	// with its second one, reporting whether they are equal.
+	Format string
+	// Equal reports whether a result of the method means equality.
+	Equal func(result reflect.Value) bool
+}
+
+// Methods are the methods comparing values, in order of preference.
+var Methods = []Method{
+	{"Equal", "bool", "%s.Equal(%s)", reflect.Value.Bool},
+	{"Cmp", "int", "%s.Cmp(%s) == 0", func(result reflect.Value) bool { return result.Int() == 0 }},
+}
+
+// Equal reports whether x and y, both of the same type, are equal.
+// x and y must be addressable, since the methods may have pointer receivers.
+func Equal(x, y reflect.Value) bool {
+	t := x.Type()
+
+	if t.Kind() == reflect.Pointer {
+		if method := methodOf(t); method != nil {
+			return x.Pointer() == y.Pointer() || !x.IsNil() && !y.IsNil() && method(x, y)
+		}
+	} else if t.Kind() != reflect.Interface {
+		if method := methodOf(t); method != nil {
+			return method(x, y)
+		}
+
+		if method := methodOf(reflect.PointerTo(t)); method != nil {
+			return method(x.Addr(), y.Addr())
+		}
+	}
+
+	if t.Comparable() {
+		return x.Interface() == y.Interface()
+	}
+
+	return reflect.DeepEqual(x.Interface(), y.Interface())
+}
+
+// methodOf returns a function calling the first of the Methods of t to
+// compare two values of type t, or nil if it has none of them.
+func methodOf(t reflect.Type) func(x, y reflect.Value) bool {
+	for _, candidate := range Methods {
+		method, found := t.MethodByName(candidate.Name)
+		if !found {
+			continue
+		}
+
+		// the receiver is the first parameter of methods of types
+		signature := method.Type
+		if signature.NumIn() != 2 || signature.NumOut() != 1 || signature.IsVariadic() {
+			continue
+		}
+
+		result := signature.Out(0)
+		if signature.In(1) != t || result.PkgPath() != "" || result.Name() != candidate.Result {
+			continue
+		}
+
+		name, equal := candidate.Name, candidate.Equal
+
+		return func(x, y reflect.Value) bool {
+			return equal(x.MethodByName(name).Call([]reflect.Value{y})[0])
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// Package tags parses the options of fields given by their mutate struct tag,
+// or by the configuration file, for the generated and runtime mutators.
+package tags
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Key is the struct tag key holding the options of a field, e.g.
+//
+//	ID   string `mutate:"immutable"`
+//	Name string `mutate:"name=Company name"`
+const Key = "mutate"
+
+// Options are the options of a field.
+type Options struct {
+	// Skip is set by "-", the field cannot be mutated.
+	Skip bool
+	// Readonly is set by "readonly", the field cannot be set, but its
+	// elements or fields may still be mutated through navigation.
+	Readonly bool
+	// Immutable is set by "immutable", the field may only be set while it
+	// has its zero value, and cannot be navigated.
+	Immutable bool
+	// Name is set by "name=<name>", and replaces the name of the field in changes.
+	Name string
+	// NoEnum is set by "noenum", the generated setter of the field, whose
+	// type is an enum, accepts any value instead of only its constants.
+	NoEnum bool
+}
+
+// Settable reports whether the field can be set.
+func (o Options) Settable() bool {
+	return !o.Readonly
+}
+
+// Mutable reports whether elements can be added to or removed from the field.
+func (o Options) Mutable() bool {
+	return !o.Readonly && !o.Immutable
+}
+
+// Navigable reports whether the field can be navigated.
+func (o Options) Navigable() bool {
+	return !o.Immutable
+}
+
+// DisplayName returns the name in changes of the field named fieldName.
+func (o Options) DisplayName(fieldName string) string {
+	if o.Name != "" {
+		return o.Name
+	}
+
+	return fieldName
+}
+
+// Lookup returns the options of the mutate key of the struct tag of a field.
+func Lookup(tag reflect.StructTag) (Options, error) {
+	value, found := tag.Lookup(Key)
+	if !found {
+		return Options{}, nil
+	}
+
+	return Parse(value)
+}
+
+// Parse parses the comma-separated options of a field.
+func Parse(value string) (Options, error) {
+	var options Options
+
+	if value == "-" {
+		options.Skip = true
+		return options, nil
+	}
+
+	for _, option := range strings.Split(value, ",") {
+		option = strings.TrimSpace(option)
+
+		switch {
+		case option == "":
+			continue
+		case option == "readonly":
+			options.Readonly = true
+		case option == "immutable":
+			options.Immutable = true
+		case option == "noenum":
+			options.NoEnum = true
+		case strings.HasPrefix(option, "name="):
+			options.Name = strings.TrimSpace(strings.TrimPrefix(option, "name="))
+			if options.Name == "" {
+				return options, errors.New("empty name option")
+			}
+		default:
+			return options, fmt.Errorf("unknown option %q", option)
+		}
+	}
+
+	if options.Readonly && options.Immutable {
+		return options, errors.New("readonly and immutable options are mutually exclusive")
+	}
+
+	return options, nil
+}
@@ -0,0 +1,327 @@
+// Package mutate provides mutators working through reflection, for types
+// whose mutators cannot be generated, like those of other modules. They log
+// the same changes as the generated mutators, e.g.
+//
+//	m := mutate.New(&acme)
+//	m.Set("YearOfBirth", 2019)
+//	m.Field("Employees").Index(0).Set("Name", "John Smith")
+//	fmt.Println(m.FormatChanges())
+//
+// outputs, like NewMutatorAcme(&acme).EmployeesAt(0).SetName("John Smith"):
+//
+//	[YearOfBirth updated from '2018' to '2019' Employees[John Doe] Name updated from 'John Doe' to 'John Smith']
+//
+// Like the reflect package, mutators panic when misused, e.g. when given
+// unknown fields or values of other types than those of the fields, where the
+// generated mutators would not compile.
+package mutate
+
+import (
+	"fmt"
+	"reflect"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pdcalado/gomutate/changes"
+	"github.com/pdcalado/gomutate/internal/tags"
+)
+
+// Mutator mutates a struct, or a slice or map held by a field of a struct or
+// by an element of a slice or map.
+//
+// The methods of mutators are given the name of the mutated field of their
+// struct, e.g. Set("Name", "Acme Inc.") like SetName("Acme Inc."). Mutators of
+// slices and maps, returned by Field, Index and Key, mutate the slice or map
+// itself, and are given an empty name, e.g. Field("Shifts").Append("", shift)
+// like AppendShifts(shift).
+type Mutator struct {
+	get func() reflect.Value
+	set func(reflect.Value)
+	// name is the name of the field holding a slice or map in changes, empty
+	// for structs and for slices and maps held by elements of slices and maps.
+	name    changes.FieldName
+	options tags.Options
+	changes changes.Logger
+}
+
+// New creates a new mutator for obj, which must be a pointer to a struct.
+func New(obj interface{}, options ...func(*Mutator)) *Mutator {
+	value := reflect.ValueOf(obj)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("mutate: %T is not a pointer to a struct", obj))
+	}
+
+	m := newStructMutator(value.Elem(), changes.NewDefaultLogger(changes.PrefixEmpty))
+
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
+}
+
+// WithChangeLogger sets the change logger of the mutator.
+func WithChangeLogger(logger changes.Logger) func(*Mutator) {
+	return func(m *Mutator) {
+		m.changes = logger
+	}
+}
+
+// FormatChanges returns the changes that were made to the object as strings
+func (m *Mutator) FormatChanges() []string {
+	return m.changes.ToString()
+}
+
+func newStructMutator(value reflect.Value, logger changes.Logger) *Mutator {
+	return &Mutator{
+		get: func() reflect.Value {
+			return value
+		},
+		changes: logger,
+	}
+}
+
+// chain returns a mutator for the struct value, logging changes with prefix.
+func (m *Mutator) chain(value reflect.Value, prefix changes.Prefix) *Mutator {
+	return newStructMutator(value, changes.NewChainedLogger(prefix, m.changes))
+}
+
+// Field returns a mutator for the field of the struct with given name, which
+// must hold a struct, a pointer to a struct, a slice or a map, like the
+// methods named after struct fields, e.g. Address(). Pointers to structs are
+// initialized if nil.
+//
+// Fields holding interfaces are navigated into the struct pointers they hold,
+// like PaymentAsCard(), and nil is returned if they hold something else.
+func (m *Mutator) Field(name string) *Mutator {
+	if m.get().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("mutate: cannot navigate field %s of %s", name, m.get().Type()))
+	}
+
+	field := m.field(name)
+	if !field.options.Navigable() {
+		panic(fmt.Sprintf("mutate: field %s of %s is immutable", name, m.get().Type()))
+	}
+
+	value := field.get()
+	prefix := changes.NewPrefix(field.name)
+
+	switch {
+	case value.Kind() == reflect.Struct:
+		return field.chain(value, prefix)
+	case value.Kind() == reflect.Pointer && value.Type().Elem().Kind() == reflect.Struct:
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+
+		return field.chain(value.Elem(), prefix)
+	case value.Kind() == reflect.Interface:
+		object := value.Elem()
+		if object.Kind() != reflect.Pointer || object.IsNil() || object.Type().Elem().Kind() != reflect.Struct {
+			return nil
+		}
+
+		return field.chain(object.Elem(), changes.NewPrefixWithKey(field.name, exportedName(object.Type().Elem().Name())))
+	case isContainer(value.Type()):
+		return field
+	}
+
+	panic(fmt.Sprintf("mutate: cannot navigate field %s of type %s", name, value.Type()))
+}
+
+// Index returns a mutator for the element at index of the slice, which must
+// hold structs, pointers to structs, slices or maps, like EmployeesAt(index).
+func (m *Mutator) Index(index int) *Mutator {
+	slice := m.get()
+	if slice.Kind() != reflect.Slice {
+		panic(fmt.Sprintf("mutate: cannot index %s", slice.Type()))
+	}
+
+	elemType := slice.Type().Elem()
+
+	if isContainer(elemType) {
+		prefix := changes.NewPrefixWithKey(m.name, changes.IntoKey(index))
+
+		return &Mutator{
+			get: func() reflect.Value {
+				return m.get().Index(index)
+			},
+			set: func(value reflect.Value) {
+				m.get().Index(index).Set(value)
+			},
+			changes: changes.NewChainedLogger(prefix, m.changes),
+		}
+	}
+
+	object := slice.Index(index)
+	if object.Kind() == reflect.Struct {
+		object = object.Addr()
+	}
+
+	if !isStructPointer(object.Type()) {
+		panic(fmt.Sprintf("mutate: cannot navigate elements of type %s", elemType))
+	}
+
+	return m.chain(object.Elem(), m.elemPrefix(object, index))
+}
+
+// Key returns a mutator for the element of the map with given key, which must
+// hold pointers to structs, slices or maps, like NicknamesWithKey(key).
+// Elements holding struct values are not addressable, and cannot be mutated.
+func (m *Mutator) Key(key interface{}) *Mutator {
+	mapValue := m.get()
+	if mapValue.Kind() != reflect.Map {
+		panic(fmt.Sprintf("mutate: cannot get keys of %s", mapValue.Type()))
+	}
+
+	keyValue := valueOf(key, mapValue.Type().Key())
+	elemType := mapValue.Type().Elem()
+
+	if isContainer(elemType) {
+		prefix := changes.NewPrefixWithKey(m.name, changes.IntoKey(key))
+
+		return &Mutator{
+			get: func() reflect.Value {
+				return mapIndex(m.get(), keyValue)
+			},
+			set: func(value reflect.Value) {
+				current := m.get()
+				if current.IsNil() {
+					current = reflect.MakeMap(current.Type())
+					m.set(current)
+				}
+				current.SetMapIndex(keyValue, value)
+			},
+			changes: changes.NewChainedLogger(prefix, m.changes),
+		}
+	}
+
+	if !isStructPointer(elemType) {
+		panic(fmt.Sprintf("mutate: cannot navigate elements of type %s", elemType))
+	}
+
+	object := mapIndex(mapValue, keyValue)
+
+	return m.chain(object.Elem(), m.elemPrefix(object, key))
+}
+
+// elemPrefix returns the prefix of the changes of the struct pointed to by
+// object, an element of the slice or map at key. Like the generated mutators,
+// the elements of fields are identified by the objects, e.g. Employees[John Doe]
+// for an *Employee implementing changes.Key, and those of nested slices and
+// maps by their key, e.g. Tags[env][2].
+func (m *Mutator) elemPrefix(object reflect.Value, key interface{}) changes.Prefix {
+	if m.name == changes.FieldNameEmpty {
+		return changes.NewPrefixWithKey(changes.FieldNameEmpty, changes.IntoKey(key))
+	}
+
+	return changes.NewPrefixWithKey(m.name, changes.IntoKey(object.Interface()))
+}
+
+// field returns a mutator for the field of the struct with given name,
+// declared by the struct or promoted from its embedded structs, which are
+// navigated like with Field.
+func (m *Mutator) field(name string) *Mutator {
+	structType := m.get().Type()
+
+	structField, found := structType.FieldByName(name)
+	if !found {
+		panic(fmt.Sprintf("mutate: no field %s in %s", name, structType))
+	}
+
+	owner := m
+	for _, index := range structField.Index[:len(structField.Index)-1] {
+		if owner = owner.Field(owner.get().Type().Field(index).Name); owner == nil {
+			panic(fmt.Sprintf("mutate: no field %s in %s", name, structType))
+		}
+	}
+
+	structField = owner.get().Type().Field(structField.Index[len(structField.Index)-1])
+
+	options, err := tags.Lookup(structField.Tag)
+	if err != nil {
+		panic(fmt.Sprintf("mutate: field %s of %s: %v", name, owner.get().Type(), err))
+	}
+
+	if options.Skip {
+		panic(fmt.Sprintf("mutate: no field %s in %s", name, structType))
+	}
+
+	if !structField.IsExported() {
+		panic(fmt.Sprintf("mutate: field %s of %s is not exported", name, owner.get().Type()))
+	}
+
+	value := owner.get().FieldByIndex(structField.Index)
+
+	return &Mutator{
+		get: func() reflect.Value {
+			return value
+		},
+		set:     value.Set,
+		name:    changes.FieldName(options.DisplayName(structField.Name)),
+		options: options,
+		changes: owner.changes,
+	}
+}
+
+// target returns the mutator of the field with given name, or m itself, a
+// mutator of a slice or map, if name is empty.
+func (m *Mutator) target(name string) *Mutator {
+	if m.get().Kind() == reflect.Struct {
+		return m.field(name)
+	}
+
+	if name != "" {
+		panic(fmt.Sprintf("mutate: no field %s in %s", name, m.get().Type()))
+	}
+
+	return m
+}
+
+// exportedName upper-cases the first letter of name, like the generated
+// mutators name the implementations of interfaces in changes, e.g.
+// Payment[Voucher] for a *voucher.
+func exportedName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
+
+func isContainer(t reflect.Type) bool {
+	return t.Kind() == reflect.Map || t.Kind() == reflect.Slice && !isByteSlice(t)
+}
+
+func isByteSlice(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+func isStructPointer(t reflect.Type) bool {
+	return t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct
+}
+
+// mapIndex returns the element of mapValue with key, or the zero value of its
+// elements if not found.
+func mapIndex(mapValue, key reflect.Value) reflect.Value {
+	if elem := mapValue.MapIndex(key); elem.IsValid() {
+		return elem
+	}
+
+	return reflect.Zero(mapValue.Type().Elem())
+}
+
+// valueOf returns an addressable copy of value, which must be assignable to
+// type t, or the zero value of t if value is nil.
+func valueOf(value interface{}, t reflect.Type) reflect.Value {
+	result := reflect.New(t).Elem()
+	if value == nil {
+		return result
+	}
+
+	v := reflect.ValueOf(value)
+	if !v.Type().AssignableTo(t) {
+		panic(fmt.Sprintf("mutate: value of type %s is not assignable to type %s", v.Type(), t))
+	}
+
+	result.Set(v)
+
+	return result
+}
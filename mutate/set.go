@@ -0,0 +1,199 @@
+package mutate
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/pdcalado/gomutate/changes"
+	"github.com/pdcalado/gomutate/internal/equality"
+)
+
+// Set sets the field of the struct with given name to value, like SetName(value),
+// reporting whether it changed. Like the generated setters, fields holding
+// structs are set to a pointer to a struct, and their changes are always
+// reported, unless value is given as a struct, which is then compared with the
+// field like other values. Setters of the fields of embedded structs are
+// promoted, e.g. Set("CreatedBy", "admin") like Audit().SetCreatedBy("admin").
+//
+// Mutators of slices and maps are set with an empty name.
+func (m *Mutator) Set(name string, value interface{}) bool {
+	target := m.target(name)
+	if !target.options.Settable() {
+		panic(fmt.Sprintf("mutate: field %s is readonly", name))
+	}
+
+	current := target.get()
+	if target.options.Immutable && !current.IsZero() {
+		return false
+	}
+
+	fieldType := current.Type()
+
+	switch {
+	case isByteSlice(fieldType):
+		return target.setBytes(valueOf(value, fieldType))
+	case isContainer(fieldType):
+		return target.setContainer(valueOf(value, fieldType))
+	case fieldType.Kind() == reflect.Pointer:
+		return target.setPointer(valueOf(value, fieldType))
+	case fieldType.Kind() == reflect.Struct && reflect.TypeOf(value) == reflect.PointerTo(fieldType):
+		target.setObject(reflect.ValueOf(value))
+	default:
+		return target.setValue(valueOf(value, fieldType))
+	}
+
+	return true
+}
+
+func (m *Mutator) setValue(value reflect.Value) bool {
+	current := m.get()
+	if equality.Equal(current, value) {
+		return false
+	}
+
+	changes.LogSet(m.changes, string(m.name), current.Interface(), value.Interface(), current.IsZero(), value.IsZero())
+	m.set(value)
+
+	return true
+}
+
+func (m *Mutator) setBytes(value reflect.Value) bool {
+	current := m.get()
+	if bytes.Equal(current.Bytes(), value.Bytes()) {
+		return false
+	}
+
+	changes.LogSetBytes(m.changes, string(m.name), current.Bytes(), value.Bytes())
+	m.set(value)
+
+	return true
+}
+
+func (m *Mutator) setContainer(value reflect.Value) bool {
+	current := m.get()
+	if value.Len() == 0 && current.Len() == 0 {
+		return false
+	}
+
+	changes.LogSetContainer(m.changes, string(m.name), current.Interface(), value.Interface(), value.Len() == 0)
+	m.set(value)
+
+	return true
+}
+
+func (m *Mutator) setPointer(value reflect.Value) bool {
+	current := m.get()
+	if value.IsNil() && current.IsNil() {
+		return false
+	}
+
+	if equality.Equal(current, value) {
+		return false
+	}
+
+	changes.LogSetPointer(m.changes, string(m.name), current.Interface(), value.Interface())
+	m.set(value)
+
+	return true
+}
+
+func (m *Mutator) setObject(value reflect.Value) {
+	changes.LogSetObject(m.changes, string(m.name), m.get().Interface(), value.Interface())
+	m.set(value.Elem())
+}
+
+// Append appends values to the slice field with given name, like
+// AppendEmployees(values...). Mutators of slices are given an empty name.
+func (m *Mutator) Append(name string, values ...interface{}) {
+	target := m.target(name)
+	if !target.options.Mutable() {
+		panic(fmt.Sprintf("mutate: cannot append to field %s", name))
+	}
+
+	current := target.get()
+	if current.Kind() != reflect.Slice {
+		panic(fmt.Sprintf("mutate: cannot append to %s", current.Type()))
+	}
+
+	elems := make([]reflect.Value, len(values))
+	for i := range values {
+		elems[i] = valueOf(values[i], current.Type().Elem())
+	}
+
+	changes.LogAppend(target.changes, string(target.name), reflect.Append(reflect.Zero(current.Type()), elems...).Interface())
+	target.set(reflect.Append(current, elems...))
+}
+
+// Insert inserts value with key into the map field with given name, like
+// InsertNicknames(key, value), reporting whether it changed. Mutators of maps
+// are given an empty name.
+func (m *Mutator) Insert(name string, key, value interface{}) bool {
+	target := m.target(name)
+	if !target.options.Mutable() {
+		panic(fmt.Sprintf("mutate: cannot insert into field %s", name))
+	}
+
+	current := target.get()
+	if current.Kind() != reflect.Map {
+		panic(fmt.Sprintf("mutate: cannot insert into %s", current.Type()))
+	}
+
+	keyValue := valueOf(key, current.Type().Key())
+	elem := valueOf(value, current.Type().Elem())
+
+	// map elements are not addressable, their copy is compared
+	if currentValue := current.MapIndex(keyValue); currentValue.IsValid() {
+		if equality.Equal(valueOf(currentValue.Interface(), elem.Type()), elem) {
+			return false
+		}
+	}
+
+	changes.LogInsert(target.changes, string(target.name), keyValue.Interface(), elem.Interface())
+
+	if current.IsNil() {
+		current = reflect.MakeMap(current.Type())
+		target.set(current)
+	}
+
+	current.SetMapIndex(keyValue, elem)
+
+	return true
+}
+
+// Remove removes the element at key from the slice or map field with given
+// name, like RemoveNicknames(key), reporting whether it changed. Elements of
+// slices are given by their index, and are always removed. Mutators of slices
+// and maps are given an empty name.
+func (m *Mutator) Remove(name string, key interface{}) bool {
+	target := m.target(name)
+	if !target.options.Mutable() {
+		panic(fmt.Sprintf("mutate: cannot remove from field %s", name))
+	}
+
+	current := target.get()
+
+	switch current.Kind() {
+	case reflect.Slice:
+		index := valueOf(key, reflect.TypeOf(0)).Interface().(int)
+
+		changes.LogRemoveIndex(target.changes, string(target.name), current.Index(index).Interface())
+		target.set(reflect.AppendSlice(current.Slice(0, index), current.Slice(index+1, current.Len())))
+
+		return true
+	case reflect.Map:
+		keyValue := valueOf(key, current.Type().Key())
+
+		currentValue := current.MapIndex(keyValue)
+		if !currentValue.IsValid() {
+			return false
+		}
+
+		changes.LogRemoveKey(target.changes, string(target.name), keyValue.Interface(), currentValue.Interface())
+		current.SetMapIndex(keyValue, reflect.Value{})
+
+		return true
+	}
+
+	panic(fmt.Sprintf("mutate: cannot remove from %s", current.Type()))
+}
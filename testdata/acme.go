@@ -1,25 +1,138 @@
 package main
 
+//go:generate go run ..
+
 import (
 	"fmt"
+	"math/big"
 	"time"
+
+	"github.com/pdcalado/gomutate/testdata/billing"
+	"github.com/pdcalado/gomutate/testdata/m"
 )
 
 type Acme struct {
+	Audit
+	ID          string `mutate:"immutable"`
 	Name        string
 	YearOfBirth int
 	Employees   []*Employee
 	Address     *Address
-	Vat         Vat
+	Vat         Vat `mutate:"readonly"`
 	Nicknames   map[string]*Employee
 	Equity      map[*Employee]int
+	Billing     billing.Account
+	Tags        map[string][]Tag
+	Shifts      [][]string
+	Regions     map[string]map[int]string
+	Secret      string `mutate:"-"`
+	Hires       Page[*Employee]
+	Status      Status
+	Payment     PaymentMethod
+	Capital     *big.Int
+	Metrics     *m.Metrics
+	Height      Meters `mutate:"noenum"`
+	// Scores has interface keys, which satisfy comparable since go1.20.
+	Scores map[any]int
+}
+
+func (a *Acme) KeyForChanges() string {
+	return a.Name
+}
+
+// Audit is embedded in other types, its Name is shadowed by theirs.
+type Audit struct {
+	Name      string
+	CreatedBy string
+	Revision  int
+}
+
+type Status string
+
+const (
+	StatusActive    Status = "active"
+	StatusSuspended Status = "suspended"
+	StatusClosed    Status = "closed"
+)
+
+// Meters is an enum, since MaxHeight is declared with it, fields of the type
+// opt out with the noenum option to accept any value.
+type Meters float64
+
+const MaxHeight Meters = 8848
+
+// Page is generic, it gets a generic mutator when generated as a root type,
+// and a mutator for each instantiation used by other types.
+type Page[T any] struct {
+	Items  []T
+	Cursor string
+	Last   T
+	Next   *Page[T]
+}
+
+type Tag struct {
+	Name  string
+	Color string
+}
+
+type Supplier struct {
+	Name    string
+	Contact *Employee `mutate:"name=Main contact"`
+	Clients []*Acme
+}
+
+// Department is recursive, the mutators of its subdepartments at any depth
+// are those of Department, and it is mutually recursive with Team. Like those
+// of Acme and Supplier, its employees are mutated by the mutator of Employee,
+// whose changes have the same prefixes whichever path leads to them.
+type Department struct {
+	Name           string
+	Head           *Employee
+	Subdepartments []*Department
+	Teams          map[string]*Team
+}
+
+func (d *Department) KeyForChanges() string {
+	return d.Name
+}
+
+type Team struct {
+	Name    string
+	Members []*Employee
+	// Spinoff is the department created from the team, if any.
+	Spinoff *Department
+}
+
+func (t *Team) KeyForChanges() string {
+	return t.Name
+}
+
+// Order and OrderLine declare LineItems and Items, whose prefix constants would
+// both be named MutationPrefixOrderLineItems, and are named
+// MutationPrefixOrder_LineItems and MutationPrefixOrderLine_Items instead.
+type Order struct {
+	LineItems []*Item
+	Line      *OrderLine
+}
+
+type OrderLine struct {
+	Items []*Item
+}
+
+type Item struct {
+	SKU      string
+	Quantity int
+}
+
+func (i *Item) KeyForChanges() string {
+	return i.SKU
 }
 
 type Address struct {
 	Street   string
 	Number   int
 	City     string
-	Zip      int
+	Zip      int `mutate:"name=Postal code"`
 	Location *string
 }
 
@@ -30,17 +143,13 @@ func (a *Address) String() string {
 	return fmt.Sprintf("{%s %d, %s %d}", a.Street, a.Number, a.City, a.Zip)
 }
 
-type Vat struct {
-	Number string
-	Type   string
-}
-
 type Employee struct {
 	Name     string
 	Position string
 	Wage     int
 	JoinedAt time.Time
 	Projects []Project
+	*Audit
 }
 
 func (e *Employee) String() string {
@@ -0,0 +1,58 @@
+package billing
+
+// Account is generated in package pattern mode, see the Makefile, with its
+// unexported fields in this package, and without them in package billingmut.
+//
+//gomutate:generate
+type Account struct {
+	// IBAN is the International Bank Account Number,
+	// e.g. PT50000201231234567890154.
+	IBAN     string `json:"iban"`
+	Holder   string // name of the account holder
+	Limits   *Limits
+	Currency Currency
+	Funding  Funding
+
+	balance int
+	history []entry
+}
+
+// entry is unexported, it is only mutated with -unexported, see the Makefile.
+type entry struct {
+	amount int
+	note   string
+}
+
+// Funding is implemented by *Transfer, and by *Token, which has no exported
+// fields and gets no mutator in package billingmut.
+type Funding interface {
+	Fund(amount int)
+}
+
+type Transfer struct {
+	Reference string
+}
+
+func (t *Transfer) Fund(amount int) {}
+
+type Token struct {
+	value string
+}
+
+func (t *Token) Fund(amount int) {}
+
+type Limits struct {
+	Daily   int
+	Monthly int
+}
+
+type Currency int
+
+const (
+	CurrencyEUR Currency = iota
+	CurrencyUSD
+	CurrencyGBP
+
+	// CurrencyDefault shares the value of CurrencyEUR.
+	CurrencyDefault = CurrencyEUR
+)
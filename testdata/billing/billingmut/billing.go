@@ -0,0 +1,151 @@
+// Code generated by gomutate; DO NOT EDIT.
+package billingmut
+
+import (
+	"github.com/pdcalado/gomutate/changes"
+	"github.com/pdcalado/gomutate/testdata/billing"
+)
+
+// MutatorAccount mutates the billing.Account object.
+type MutatorAccount struct {
+	inner   *billing.Account
+	changes changes.Logger
+}
+
+// NewMutatorAccount creates a new mutator for the billing.Account object.
+func NewMutatorAccount(
+	obj *billing.Account,
+	options ...func(*MutatorAccount),
+) *MutatorAccount {
+	m := &MutatorAccount{
+		inner:   obj,
+		changes: changes.NewDefaultLogger(changes.PrefixEmpty),
+	}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
+}
+
+// WithChangeLoggerAccount sets the change logger for the billing.Account mutator.
+func WithChangeLoggerAccount(logger changes.Logger) func(*MutatorAccount) {
+	return func(m *MutatorAccount) {
+		m.changes = logger
+	}
+}
+
+// FormatChanges returns the changes that were made to the object as strings
+func (m *MutatorAccount) FormatChanges() []string {
+	return m.changes.ToString()
+}
+
+type MutatorLimits struct {
+	inner   *billing.Limits
+	changes changes.Logger
+}
+
+func NewMutatorLimits(obj *billing.Limits, changes changes.Logger) *MutatorLimits {
+	return &MutatorLimits{
+		inner:   obj,
+		changes: changes,
+	}
+}
+
+type MutatorTransfer struct {
+	inner   *billing.Transfer
+	changes changes.Logger
+}
+
+func NewMutatorTransfer(obj *billing.Transfer, changes changes.Logger) *MutatorTransfer {
+	return &MutatorTransfer{
+		inner:   obj,
+		changes: changes,
+	}
+}
+
+const (
+	MutationPrefixAccountFunding changes.FieldName = "Funding"
+	MutationPrefixAccountLimits  changes.FieldName = "Limits"
+)
+
+// UpdateIBAN mutates the IBAN of the billing.Account object
+func (m *MutatorAccount) UpdateIBAN(value string) bool {
+	return changes.Set(m.changes, "IBAN", &m.inner.IBAN, value, m.inner.IBAN == value)
+}
+
+// UpdateHolder mutates the Holder of the billing.Account object
+func (m *MutatorAccount) UpdateHolder(value string) bool {
+	return changes.Set(m.changes, "Holder", &m.inner.Holder, value, m.inner.Holder == value)
+}
+
+// UpdateDaily mutates the Daily of the billing.Limits object
+func (m *MutatorLimits) UpdateDaily(value int) bool {
+	return changes.Set(m.changes, "Daily", &m.inner.Daily, value, m.inner.Daily == value)
+}
+
+// UpdateMonthly mutates the Monthly of the billing.Limits object
+func (m *MutatorLimits) UpdateMonthly(value int) bool {
+	return changes.Set(m.changes, "Monthly", &m.inner.Monthly, value, m.inner.Monthly == value)
+}
+
+// UpdateLimits sets Limits of the billing.Account object
+func (m *MutatorAccount) UpdateLimits(value *billing.Limits) bool {
+	return changes.SetPointer(m.changes, "Limits", &m.inner.Limits, value, m.inner.Limits == value)
+}
+
+// Limits returns a mutator for Limits of the billing.Account object.
+// If the field is nil, it will be initialized to a new billing.Limits object.
+func (m *MutatorAccount) Limits() *MutatorLimits {
+
+	if m.inner.Limits == nil {
+		m.inner.Limits = &billing.Limits{}
+	}
+
+	prefix := changes.NewPrefix(MutationPrefixAccountLimits)
+
+	return &MutatorLimits{
+		inner:   m.inner.Limits,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// enumNamesCurrency maps the declared billing.Currency constants to their names.
+var enumNamesCurrency = map[billing.Currency]string{
+	billing.CurrencyEUR: "CurrencyEUR",
+	billing.CurrencyUSD: "CurrencyUSD",
+	billing.CurrencyGBP: "CurrencyGBP",
+}
+
+// UpdateCurrency mutates the Currency of the billing.Account object,
+// values other than the declared billing.Currency constants are rejected.
+func (m *MutatorAccount) UpdateCurrency(value billing.Currency) bool {
+	return changes.SetEnum(m.changes, "Currency", &m.inner.Currency, value, enumNamesCurrency)
+}
+
+// UpdateReference mutates the Reference of the billing.Transfer object
+func (m *MutatorTransfer) UpdateReference(value string) bool {
+	return changes.Set(m.changes, "Reference", &m.inner.Reference, value, m.inner.Reference == value)
+}
+
+// UpdateFunding mutates the Funding of the billing.Account object
+func (m *MutatorAccount) UpdateFunding(value billing.Funding) bool {
+	return changes.Set(m.changes, "Funding", &m.inner.Funding, value, m.inner.Funding == value)
+}
+
+// FundingAsTransfer returns a mutator for Funding of the billing.Account object
+// if it holds a *billing.Transfer, or nil otherwise.
+func (m *MutatorAccount) FundingAsTransfer() *MutatorTransfer {
+	object, isImpl := m.inner.Funding.(*billing.Transfer)
+	if !isImpl || object == nil {
+		return nil
+	}
+
+	prefix := changes.NewPrefixWithKey(MutationPrefixAccountFunding, "Transfer")
+
+	return &MutatorTransfer{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
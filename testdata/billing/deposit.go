@@ -0,0 +1,7 @@
+package billing
+
+// Deposit adds amount to the balance of the account. Mutators may be extended
+// with methods of their own, as long as their names are not generated.
+func (m *MutatorAccount) Deposit(amount int) bool {
+	return m.UpdateBalance(m.inner.balance + amount)
+}
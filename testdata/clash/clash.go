@@ -0,0 +1,18 @@
+// Package clash declares types whose mutators would have the same name, and
+// fields with options their types do not support, which gomutate reports
+// instead of generating code that does not compile, see the Makefile.
+package clash
+
+type Inner struct {
+	Name string
+}
+
+// SliceInner is named like the mutator of []Inner, MutatorSliceInner.
+type SliceInner struct {
+	Name string
+}
+
+type Outer struct {
+	Grid  [][]Inner
+	Other SliceInner
+}
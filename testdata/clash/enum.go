@@ -0,0 +1,10 @@
+package clash
+
+type Kind string
+
+const KindPrimary Kind = "primary"
+
+// Kinds opts a field out of enums, although []Kind is not an enum.
+type Kinds struct {
+	All []Kind `mutate:"noenum"`
+}
@@ -0,0 +1,7 @@
+// Package directive holds its go:generate directive in a file of its own,
+// generating the mutators of the types of its configuration file, which are
+// declared by other files. They are also generated in package pattern mode
+// with patterns.json, which only applies to this package, see the Makefile.
+package directive
+
+//go:generate go run ../..
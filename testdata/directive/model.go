@@ -0,0 +1,10 @@
+package directive
+
+type Root struct {
+	Name  string
+	Nodes []*Node
+}
+
+type Node struct {
+	Value int
+}
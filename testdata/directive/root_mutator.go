@@ -0,0 +1,104 @@
+// Code generated by gomutate; DO NOT EDIT.
+package directive
+
+import (
+	"github.com/pdcalado/gomutate/changes"
+)
+
+// MutatorRoot mutates the Root object.
+type MutatorRoot struct {
+	inner   *Root
+	changes changes.Logger
+}
+
+// NewMutatorRoot creates a new mutator for the Root object.
+func NewMutatorRoot(
+	obj *Root,
+	options ...func(*MutatorRoot),
+) *MutatorRoot {
+	m := &MutatorRoot{
+		inner:   obj,
+		changes: changes.NewDefaultLogger(changes.PrefixEmpty),
+	}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
+}
+
+// WithChangeLoggerRoot sets the change logger for the Root mutator.
+func WithChangeLoggerRoot(logger changes.Logger) func(*MutatorRoot) {
+	return func(m *MutatorRoot) {
+		m.changes = logger
+	}
+}
+
+// FormatChanges returns the changes that were made to the object as strings
+func (m *MutatorRoot) FormatChanges() []string {
+	return m.changes.ToString()
+}
+
+type MutatorNode struct {
+	inner   *Node
+	changes changes.Logger
+}
+
+func NewMutatorNode(obj *Node, changes changes.Logger) *MutatorNode {
+	return &MutatorNode{
+		inner:   obj,
+		changes: changes,
+	}
+}
+
+const (
+	MutationPrefixRootNodes changes.FieldName = "Nodes"
+)
+
+// SetName mutates the Name of the Root object
+func (m *MutatorRoot) SetName(value string) bool {
+	return changes.Set(m.changes, "Name", &m.inner.Name, value, m.inner.Name == value)
+}
+
+// SetValue mutates the Value of the Node object
+func (m *MutatorNode) SetValue(value int) bool {
+	return changes.Set(m.changes, "Value", &m.inner.Value, value, m.inner.Value == value)
+}
+
+// SetNodes sets Nodes of the Root object
+func (m *MutatorRoot) SetNodes(value []*Node) bool {
+	return changes.SetSlice(m.changes, "Nodes", &m.inner.Nodes, value)
+}
+
+// AppendNodes appends a Nodes element of the Root object.
+func (m *MutatorRoot) AppendNodes(value ...*Node) {
+	changes.Append(m.changes, "Nodes", &m.inner.Nodes, value...)
+}
+
+// RemoveNodes removes a Nodes element of the Root object.
+func (m *MutatorRoot) RemoveNodes(index int) {
+	changes.RemoveIndex(m.changes, "Nodes", &m.inner.Nodes, index)
+}
+
+// NodesAt returns a mutator for Nodes element at index of the Root object.
+func (m *MutatorRoot) NodesAt(index int) *MutatorNode {
+	object := m.inner.Nodes[index]
+
+	prefix := changes.NewPrefixWithKey(MutationPrefixRootNodes, changes.IntoKey(object))
+
+	return &MutatorNode{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// NodesByPtr returns a mutator for Nodes element given by a pointer of type Root.
+func (m *MutatorRoot) NodesByPtr(ptr *Node) *MutatorNode {
+	for i, item := range m.inner.Nodes {
+		if item == ptr {
+			return m.NodesAt(i)
+		}
+	}
+	return nil
+}
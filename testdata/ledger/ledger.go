@@ -0,0 +1,13 @@
+package ledger
+
+import "github.com/pdcalado/gomutate/testdata/money.v1"
+
+// Invoice is generated by moneygen, see the Makefile, its money.Amount fields
+// are set and added to with the methods of the field handler of moneygen.
+//
+//gomutate:generate
+type Invoice struct {
+	Number string
+	Total  money.Amount
+	Paid   money.Amount `mutate:"readonly"`
+}
@@ -0,0 +1,74 @@
+// Code generated by gomutate; DO NOT EDIT.
+package ledger
+
+import (
+	"github.com/pdcalado/gomutate/changes"
+	"github.com/pdcalado/gomutate/testdata/money.v1"
+)
+
+// MutatorInvoice mutates the Invoice object.
+type MutatorInvoice struct {
+	inner   *Invoice
+	changes changes.Logger
+}
+
+// NewMutatorInvoice creates a new mutator for the Invoice object.
+func NewMutatorInvoice(
+	obj *Invoice,
+	options ...func(*MutatorInvoice),
+) *MutatorInvoice {
+	m := &MutatorInvoice{
+		inner:   obj,
+		changes: changes.NewDefaultLogger(changes.PrefixEmpty),
+	}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
+}
+
+// WithChangeLoggerInvoice sets the change logger for the Invoice mutator.
+func WithChangeLoggerInvoice(logger changes.Logger) func(*MutatorInvoice) {
+	return func(m *MutatorInvoice) {
+		m.changes = logger
+	}
+}
+
+// FormatChanges returns the changes that were made to the object as strings
+func (m *MutatorInvoice) FormatChanges() []string {
+	return m.changes.ToString()
+}
+
+// SetNumber mutates the Number of the Invoice object
+func (m *MutatorInvoice) SetNumber(value string) bool {
+	return changes.Set(m.changes, "Number", &m.inner.Number, value, m.inner.Number == value)
+}
+
+// setAmount sets field to value, changes report the amounts as strings.
+func setAmount(logger changes.Logger, name string, field *money.Amount, value money.Amount) bool {
+	if field.Equal(value) {
+		return false
+	}
+
+	logger.Append(changes.Change{
+		FieldName: name,
+		Operation: changes.OperationUpdated,
+		OldValue:  field.String(),
+		NewValue:  value.String(),
+	})
+	*field = value
+
+	return true
+}
+
+// SetTotal sets the Total field, amounts with the same value are equal.
+func (m *MutatorInvoice) SetTotal(value money.Amount) bool {
+	return setAmount(m.changes, "Total", &m.inner.Total, value)
+}
+
+// AddTotal adds value to the Total field.
+func (m *MutatorInvoice) AddTotal(value money.Amount) bool {
+	return setAmount(m.changes, "Total", &m.inner.Total, m.inner.Total.Add(value))
+}
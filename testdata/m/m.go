@@ -0,0 +1,7 @@
+// Package m is named like the receivers of the generated methods, it is
+// imported under another name by the mutators of Acme.
+package m
+
+type Metrics struct {
+	Visits int
+}
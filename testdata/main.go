@@ -3,7 +3,17 @@ package main
 import (
 	"fmt"
 	"log"
+	"math/big"
+	"reflect"
+	"strings"
 	"time"
+
+	"github.com/pdcalado/gomutate/changes"
+	"github.com/pdcalado/gomutate/mutate"
+	"github.com/pdcalado/gomutate/testdata/billing"
+	"github.com/pdcalado/gomutate/testdata/billing/billingmut"
+	"github.com/pdcalado/gomutate/testdata/ledger"
+	"github.com/pdcalado/gomutate/testdata/money.v1"
 )
 
 func assertBool(expected bool, obtained bool) {
@@ -122,6 +132,54 @@ func main() {
 	assertBool(false, mutator.RemoveNicknames("Roger Ramjet"))
 	assertBool(true, mutator.NicknamesWithKey("Janey").SetWage(50000))
 	assertBool(true, mutator.InsertEquity(acme.Employees[1], 1000))
+	assertBool(true, mutator.Billing().SetHolder("Acme Inc."))
+	assertBool(true, mutator.Billing().Limits().SetDaily(1000))
+	assertBool(false, mutator.Billing().Limits().SetDaily(1000))
+	assertBool(true, mutator.InsertTags("env", []Tag{{Name: "prod"}}))
+	assertBool(false, mutator.InsertTags("env", []Tag{{Name: "prod"}}))
+	mutator.TagsWithKey("env").Append(Tag{Name: "staging"})
+	assertBool(true, mutator.TagsWithKey("env").At(1).SetColor("blue"))
+	mutator.AppendShifts([]string{"morning"})
+	mutator.ShiftsAt(0).Append("night")
+	assertBool(true, mutator.RegionsWithKey("eu").Insert(1, "Lisbon"))
+	assertBool(false, mutator.RegionsWithKey("eu").Insert(1, "Lisbon"))
+	assertBool(true, mutator.RegionsWithKey("eu").Insert(2, "Porto"))
+	assertBool(true, mutator.RegionsWithKey("eu").Remove(1))
+	assertBool(true, mutator.SetCreatedBy("admin"))
+	assertBool(false, mutator.SetCreatedBy("admin"))
+	assertBool(true, mutator.Audit().SetName("yearly"))
+	assertBool(true, mutator.EmployeesAt(1).SetRevision(1))
+	assertBool(true, mutator.SetID("acme-1"))
+	assertBool(false, mutator.SetID("acme-2"))
+	assertBool(true, mutator.Address().SetZip(45002))
+	assertBool(true, mutator.Hires().SetCursor("2023-10"))
+	assertBool(true, mutator.Hires().Last().SetName("Rookie"))
+	assertBool(true, mutator.Hires().Next().SetCursor("2023-11"))
+	assertBool(true, mutator.SetStatus(StatusActive))
+	assertBool(true, mutator.SetStatus(StatusSuspended))
+	assertBool(false, mutator.SetStatus("bankrupt"))
+	assertBool(true, mutator.Billing().SetCurrency(billing.CurrencyUSD))
+	assertBool(false, mutator.Billing().SetCurrency(billing.Currency(42)))
+	assertBool(true, mutator.PaymentAsCard() == nil)
+	assertBool(true, mutator.SetPayment(&Card{Number: "4111"}))
+	assertBool(true, mutator.PaymentAsWire() == nil)
+	assertBool(true, mutator.PaymentAsCard().SetHolder("Acme Inc."))
+	assertBool(false, mutator.EmployeesAt(0).SetJoinedAt(now.In(time.FixedZone("CET", 3600))))
+	assertBool(true, mutator.SetCapital(big.NewInt(1000000)))
+	assertBool(false, mutator.SetCapital(big.NewInt(1000000)))
+	assertBool(true, mutator.Metrics().SetVisits(10))
+	assertBool(true, mutator.SetHeight(MaxHeight))
+	assertBool(true, mutator.SetHeight(1200.5))
+	assertBool(true, mutator.InsertScores("q1", 10))
+	assertBool(false, mutator.InsertScores("q1", 10))
+	assertBool(true, mutator.InsertScores(2, 20))
+	assertBool(true, mutator.RemoveScores("q1"))
+
+	// neither skipped nor readonly fields have setters
+	_, hasSecretSetter := interface{}(mutator).(interface{ SetSecret(string) bool })
+	assertBool(false, hasSecretSetter)
+	_, hasVatSetter := interface{}(mutator).(interface{ SetVat(*Vat) bool })
+	assertBool(false, hasVatSetter)
 
 	for _, change := range mutator.FormatChanges() {
 		fmt.Println(change)
@@ -137,4 +195,260 @@ func main() {
 	assertEqual(acme.Employees[1], acme.Nicknames["Janey"])
 	assertBool(true, acme.Nicknames["Johnny"] == nil)
 	assertEqual(50000, acme.Employees[1].Wage)
+	assertEqual("Acme Inc.", acme.Billing.Holder)
+	assertEqual(1000, acme.Billing.Limits.Daily)
+	assertEqual("blue", acme.Tags["env"][1].Color)
+	assertEqual("night", acme.Shifts[0][1])
+	assertEqual("Porto", acme.Regions["eu"][2])
+	assertEqual("admin", acme.CreatedBy)
+	assertEqual("yearly", acme.Audit.Name)
+	assertEqual(1, acme.Employees[1].Revision)
+	assertEqual("acme-1", acme.ID)
+	assertEqual(45002, acme.Address.Zip)
+	assertEqual("Rookie", acme.Hires.Last.Name)
+	assertEqual("2023-11", acme.Hires.Next.Cursor)
+	assertEqual(StatusSuspended, acme.Status)
+	assertEqual(billing.CurrencyUSD, acme.Billing.Currency)
+	assertEqual("Acme Inc.", acme.Payment.(*Card).Holder)
+	assertEqual(10, acme.Metrics.Visits)
+	assertEqual(1200.5, acme.Height)
+	assertEqual(20, acme.Scores[2])
+
+	supplier := Supplier{
+		Name:    "Roadrunner Supplies",
+		Contact: acme.Employees[1],
+		Clients: []*Acme{&acme},
+	}
+
+	supplierMutator := NewMutatorSupplier(
+		&supplier,
+		WithChangeLoggerSupplier(changes.NewDefaultLogger(changes.NewPrefix("Supplier"))),
+	)
+
+	assertBool(true, supplierMutator.Contact().SetPosition("CTO & Procurement"))
+	assertBool(false, supplierMutator.Contact().SetPosition("CTO & Procurement"))
+	assertBool(true, supplierMutator.ClientsAt(0).EmployeesAt(1).SetWage(60000))
+	assertBool(true, supplierMutator.ChangeName("Roadrunner Ltd."))
+
+	for _, change := range supplierMutator.FormatChanges() {
+		fmt.Println(change)
+	}
+
+	assertEqual("CTO & Procurement", acme.Employees[1].Position)
+	assertEqual(60000, acme.Employees[1].Wage)
+	assertEqual("Roadrunner Ltd.", supplier.Name)
+
+	department := Department{
+		Name: "Engineering",
+		Head: acme.Employees[0],
+		Subdepartments: []*Department{
+			{
+				Name: "Research",
+				Subdepartments: []*Department{
+					{Name: "Compilers", Head: acme.Employees[1]},
+				},
+			},
+		},
+		Teams: map[string]*Team{
+			"platform": {
+				Name:    "Platform",
+				Members: []*Employee{acme.Employees[1]},
+				Spinoff: &Department{
+					Subdepartments: []*Department{{Name: "Tools", Head: acme.Employees[1]}},
+				},
+			},
+		},
+	}
+
+	departmentMutator := NewMutatorDepartment(&department)
+
+	assertBool(true, departmentMutator.SubdepartmentsAt(0).SubdepartmentsAt(0).SetName("Languages"))
+	assertBool(true, departmentMutator.SubdepartmentsAt(0).SubdepartmentsAt(0).Head().ProjectsAt(0).SetValue(150000))
+	assertBool(true, departmentMutator.Head().ProjectsAt(0).SetValue(175000))
+	assertBool(true, departmentMutator.TeamsWithKey("platform").Spinoff().SetName("Platform engineering"))
+	assertBool(true, departmentMutator.TeamsWithKey("platform").Spinoff().SubdepartmentsAt(0).Head().SetWage(65000))
+
+	for _, change := range departmentMutator.FormatChanges() {
+		fmt.Println(change)
+	}
+
+	assertEqual("Languages", department.Subdepartments[0].Subdepartments[0].Name)
+	assertEqual(65000, department.Teams["platform"].Spinoff.Subdepartments[0].Head.Wage)
+
+	order := Order{
+		LineItems: []*Item{{SKU: "anvil", Quantity: 1}},
+		Line:      &OrderLine{Items: []*Item{{SKU: "rocket", Quantity: 2}}},
+	}
+	orderMutator := NewMutatorOrder(&order)
+
+	assertBool(true, orderMutator.LineItemsAt(0).SetQuantity(3))
+	assertBool(true, orderMutator.Line().ItemsAt(0).SetQuantity(4))
+
+	for _, change := range orderMutator.FormatChanges() {
+		fmt.Println(change)
+	}
+
+	assertEqual(MutationPrefixOrder_LineItems, "LineItems")
+	assertEqual(MutationPrefixOrderLine_Items, "Items")
+
+	page := Page[[]string]{}
+	pageMutator := NewMutatorPage(&page)
+
+	assertBool(true, pageMutator.SetLast([]string{"z"}))
+	assertBool(false, pageMutator.SetLast([]string{"z"}))
+	pageMutator.AppendItems([]string{"a"}, []string{"b"})
+	assertBool(true, pageMutator.Next().SetCursor("2"))
+
+	for _, change := range pageMutator.FormatChanges() {
+		fmt.Println(change)
+	}
+
+	assertEqual(2, len(page.Items))
+	assertEqual("2", page.Next.Cursor)
+
+	// generated in the billing package, as annotated
+	account := billing.Account{Holder: "Acme Inc."}
+	accountMutator := billing.NewMutatorAccount(&account)
+
+	assertBool(true, accountMutator.UpdateIBAN("PT50000201231234567890154"))
+	assertBool(true, accountMutator.Limits().UpdateMonthly(5000))
+	assertBool(true, accountMutator.Deposit(100))
+
+	for _, change := range accountMutator.FormatChanges() {
+		fmt.Println(change)
+	}
+
+	assertEqual(5000, account.Limits.Monthly)
+
+	// generated in the billingmut package, importing the billing package
+	otherAccount := billing.Account{Holder: "Acme Inc."}
+	otherAccountMutator := billingmut.NewMutatorAccount(&otherAccount)
+
+	assertBool(true, otherAccountMutator.UpdateHolder("Acme Corp."))
+	assertBool(true, otherAccountMutator.UpdateCurrency(billing.CurrencyGBP))
+	assertBool(false, otherAccountMutator.UpdateCurrency(billing.Currency(42)))
+
+	for _, change := range otherAccountMutator.FormatChanges() {
+		fmt.Println(change)
+	}
+
+	assertEqual(billing.CurrencyGBP, otherAccount.Currency)
+
+	// generated by moneygen, whose field handler generates the methods of the
+	// money.Amount fields
+	invoice := ledger.Invoice{Number: "2023-001", Total: money.New(10000, "EUR")}
+	invoiceMutator := ledger.NewMutatorInvoice(&invoice)
+
+	assertBool(false, invoiceMutator.SetTotal(money.New(10000, "EUR")))
+	assertBool(true, invoiceMutator.AddTotal(money.New(2550, "EUR")))
+
+	for _, change := range invoiceMutator.FormatChanges() {
+		fmt.Println(change)
+	}
+
+	assertEqual(money.New(12550, "EUR"), invoice.Total)
+
+	// the same code mutates objects through mutators, and records the
+	// requested mutations through fakes
+	hired := Acme{Employees: []*Employee{{Name: "John Doe"}, {Name: "Jane"}}}
+	hire(NewMutatorAcme(&hired))
+	assertEqual("Jane Doe", hired.Employees[1].Name)
+	assertEqual("Lisbon", hired.Address.City)
+
+	recorder := &changes.Recorder{}
+	hire(NewFakeAcmeMutator(recorder))
+
+	for _, call := range recorder.ToString() {
+		fmt.Println(call)
+	}
+
+	// mutated through reflection, reporting the same changes as the
+	// generated mutators
+	generated, reflected := newAcme(now), newAcme(now)
+
+	generatedMutator := NewMutatorAcme(generated)
+	assertBool(true, generatedMutator.SetYearOfBirth(2019))
+	assertBool(false, generatedMutator.SetYearOfBirth(2019))
+	assertBool(true, generatedMutator.SetID("acme-1"))
+	assertBool(true, generatedMutator.SetCreatedBy("admin"))
+	assertBool(true, generatedMutator.EmployeesAt(0).SetName("John Smith"))
+	assertBool(true, generatedMutator.EmployeesAt(0).ProjectsAt(0).SetSeqID([]byte("42")))
+	assertBool(false, generatedMutator.EmployeesAt(0).ProjectsAt(0).SetStartedAt(now.In(time.FixedZone("CET", 3600))))
+	generatedMutator.AppendEmployees(&Employee{Name: "Roger Smith"})
+	assertBool(true, generatedMutator.Address().SetZip(45002))
+	assertBool(true, generatedMutator.InsertNicknames("Johnny", generated.Employees[0]))
+	assertBool(true, generatedMutator.NicknamesWithKey("Johnny").SetWage(110000))
+	generatedMutator.TagsWithKey("env").Append(Tag{Name: "staging", Color: "blue"})
+	assertBool(true, generatedMutator.TagsWithKey("env").At(0).SetName("production"))
+	generatedMutator.ShiftsAt(0).Append("night")
+	assertBool(true, generatedMutator.RegionsWithKey("eu").Insert(1, "Lisbon"))
+	assertBool(true, generatedMutator.PaymentAsCard().SetHolder("Acme Inc."))
+	assertBool(true, generatedMutator.SetCapital(big.NewInt(1000)))
+	assertBool(false, generatedMutator.SetCapital(big.NewInt(1000)))
+	assertBool(true, generatedMutator.Hires().SetCursor("2023-10"))
+	assertBool(true, generatedMutator.Vat().SetType("Company"))
+	assertBool(true, generatedMutator.SetBilling(&billing.Account{Holder: "Acme Inc."}))
+	assertBool(true, generatedMutator.SetAddress(nil))
+	assertBool(true, generatedMutator.RemoveNicknames("Johnny"))
+	generatedMutator.RemoveEmployees(1)
+
+	reflectedMutator := mutate.New(reflected)
+	assertBool(true, reflectedMutator.Set("YearOfBirth", 2019))
+	assertBool(false, reflectedMutator.Set("YearOfBirth", 2019))
+	assertBool(true, reflectedMutator.Set("ID", "acme-1"))
+	assertBool(true, reflectedMutator.Set("CreatedBy", "admin"))
+	assertBool(true, reflectedMutator.Field("Employees").Index(0).Set("Name", "John Smith"))
+	assertBool(true, reflectedMutator.Field("Employees").Index(0).Field("Projects").Index(0).Set("SeqID", []byte("42")))
+	assertBool(false, reflectedMutator.Field("Employees").Index(0).Field("Projects").Index(0).Set("StartedAt", now.In(time.FixedZone("CET", 3600))))
+	reflectedMutator.Append("Employees", &Employee{Name: "Roger Smith"})
+	assertBool(true, reflectedMutator.Field("Address").Set("Zip", 45002))
+	assertBool(true, reflectedMutator.Insert("Nicknames", "Johnny", reflected.Employees[0]))
+	assertBool(true, reflectedMutator.Field("Nicknames").Key("Johnny").Set("Wage", 110000))
+	reflectedMutator.Field("Tags").Key("env").Append("", Tag{Name: "staging", Color: "blue"})
+	assertBool(true, reflectedMutator.Field("Tags").Key("env").Index(0).Set("Name", "production"))
+	reflectedMutator.Field("Shifts").Index(0).Append("", "night")
+	assertBool(true, reflectedMutator.Field("Regions").Key("eu").Insert("", 1, "Lisbon"))
+	assertBool(true, reflectedMutator.Field("Payment").Set("Holder", "Acme Inc."))
+	assertBool(true, reflectedMutator.Set("Capital", big.NewInt(1000)))
+	assertBool(false, reflectedMutator.Set("Capital", big.NewInt(1000)))
+	assertBool(true, reflectedMutator.Field("Hires").Set("Cursor", "2023-10"))
+	assertBool(true, reflectedMutator.Field("Vat").Set("Type", "Company"))
+	assertBool(true, reflectedMutator.Set("Billing", &billing.Account{Holder: "Acme Inc."}))
+	assertBool(true, reflectedMutator.Set("Address", nil))
+	assertBool(true, reflectedMutator.Remove("Nicknames", "Johnny"))
+	assertBool(true, reflectedMutator.Remove("Employees", 1))
+
+	assertEqual(strings.Join(generatedMutator.FormatChanges(), "\n"), strings.Join(reflectedMutator.FormatChanges(), "\n"))
+	assertBool(true, reflect.DeepEqual(generated, reflected))
+
+	// unexported implementations are named like generated mutators would name them
+	voucherMutator := mutate.New(&Acme{Payment: &voucher{}})
+	assertBool(true, voucherMutator.Field("Payment").Set("Amount", 50))
+	assertEqual("Payment[Voucher] Amount set to '50'", voucherMutator.FormatChanges()[0])
+
+	for _, change := range reflectedMutator.FormatChanges() {
+		fmt.Println(change)
+	}
+}
+
+// newAcme returns a new Acme object, mutated by the generated mutators and
+// through reflection alike.
+func newAcme(now time.Time) *Acme {
+	return &Acme{
+		Name:      "Acme Inc.",
+		Employees: []*Employee{{Name: "John Doe", Wage: 100000, Projects: []Project{{Name: "Project 1", StartedAt: now}}}},
+		Address:   &Address{Street: "Baker Street", Zip: 45001},
+		Tags:      map[string][]Tag{"env": nil},
+		Shifts:    [][]string{{"morning"}},
+		Payment:   &Card{Number: "4111"},
+	}
+}
+
+// hire depends on the interface of the mutator of Acme, so that it may be
+// tested with a fake.
+func hire(mutator AcmeMutator) {
+	mutator.SetName("Acme Corp.")
+	mutator.EmployeesAt(1).SetName("Jane Doe")
+	mutator.EmployeesAt(1).SetPosition("CFO")
+	mutator.Address().SetCity("Lisbon")
 }
@@ -0,0 +1,38 @@
+// Package money stands for a package of another module, whose Amount fields
+// are handled by the field handler of moneygen, see the Makefile.
+package money
+
+import "fmt"
+
+// Amount is an amount of money in cents of its currency, its fields are
+// unexported and cannot be mutated.
+type Amount struct {
+	cents    int64
+	currency string
+}
+
+func New(cents int64, currency string) Amount {
+	return Amount{cents: cents, currency: currency}
+}
+
+// Add returns the sum of a and b, which must have the same currency.
+func (a Amount) Add(b Amount) Amount {
+	if a.currency != "" && b.currency != "" && a.currency != b.currency {
+		panic(fmt.Sprintf("money: cannot add %s to %s", b.currency, a.currency))
+	}
+
+	sum := Amount{cents: a.cents + b.cents, currency: a.currency}
+	if sum.currency == "" {
+		sum.currency = b.currency
+	}
+
+	return sum
+}
+
+func (a Amount) Equal(b Amount) bool {
+	return a.cents == b.cents && a.currency == b.currency
+}
+
+func (a Amount) String() string {
+	return fmt.Sprintf("%d.%02d %s", a.cents/100, a.cents%100, a.currency)
+}
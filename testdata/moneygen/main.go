@@ -0,0 +1,105 @@
+// Command moneygen generates mutators like gomutate, with the money.Amount
+// fields handled by a field handler, see the Makefile.
+//
+// Usage:
+//
+//	moneygen [-check] [packages]
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/pdcalado/gomutate/generator"
+)
+
+const moneyPackage = "github.com/pdcalado/gomutate/testdata/money.v1"
+
+// amountHandler generates a setter reporting amounts as strings, and an adder
+// of amounts, unless fields are readonly.
+var amountHandler = generator.FieldHandler{
+	Match:   generator.MatchType(moneyPackage, "Amount"),
+	Setter:  true,
+	Imports: []string{moneyPackage},
+	Template: `
+{{- if not .Field.Readonly}}
+// {{.Names.Set}} sets the {{.FieldName}} field, amounts with the same value are equal.
+func (m *{{.Mutator}}) {{.Names.Set}}(value {{.FieldTypeName}}) bool {
+	{{- if .Immutable}}
+	if !changes.IsZero(&m.inner.{{.FieldName}}) {
+		return false
+	}
+	{{- end}}
+	return setAmount(m.changes, "{{.DisplayName}}", &m.inner.{{.FieldName}}, value)
+}
+
+// Add{{.Method}} adds value to the {{.FieldName}} field.
+func (m *{{.Mutator}}) Add{{.Method}}(value {{.FieldTypeName}}) bool {
+	return setAmount(m.changes, "{{.DisplayName}}", &m.inner.{{.FieldName}}, m.inner.{{.FieldName}}.Add(value))
+}
+{{end}}`,
+	Helpers: `
+// setAmount sets field to value, changes report the amounts as strings.
+func setAmount(logger changes.Logger, name string, field *money.Amount, value money.Amount) bool {
+	if field.Equal(value) {
+		return false
+	}
+
+	logger.Append(changes.Change{
+		FieldName: name,
+		Operation: changes.OperationUpdated,
+		OldValue:  field.String(),
+		NewValue:  value.String(),
+	})
+	*field = value
+
+	return true
+}
+`,
+}
+
+func main() {
+	check := flag.Bool("check", false, "check that the generated files are up to date instead of writing them")
+	flag.Parse()
+
+	files, diagnostics, err := generator.Generate(context.Background(), generator.Options{
+		Patterns:      flag.Args(),
+		FieldHandlers: []generator.FieldHandler{amountHandler},
+	})
+	for _, diagnostic := range diagnostics {
+		log.Print(diagnostic)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		if !*check {
+			if err := os.WriteFile(filename, files[filename], 0o644); err != nil {
+				log.Fatal(err)
+			}
+
+			continue
+		}
+
+		current, err := os.ReadFile(filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if !bytes.Equal(current, files[filename]) {
+			log.Fatalf("%s is not up to date", filename)
+		}
+	}
+}
@@ -3,12 +3,12 @@ package main
 
 import (
 	"fmt"
-	"encoding/base64"
-	"bytes"
-	"time"
-	"reflect"
 	"github.com/pdcalado/gomutate/changes"
-	
+	"github.com/pdcalado/gomutate/testdata/billing"
+	m2 "github.com/pdcalado/gomutate/testdata/m"
+	"math/big"
+	"reflect"
+	"time"
 )
 
 // MutatorAcme mutates the Acme object.
@@ -34,8 +34,8 @@ func NewMutatorAcme(
 	return m
 }
 
-// WithChangeLogger sets the change logger for the mutator.
-func WithChangeLogger(logger changes.Logger) func(*MutatorAcme) {
+// WithChangeLoggerAcme sets the change logger for the Acme mutator.
+func WithChangeLoggerAcme(logger changes.Logger) func(*MutatorAcme) {
 	return func(m *MutatorAcme) {
 		m.changes = logger
 	}
@@ -46,25 +46,153 @@ func (m *MutatorAcme) FormatChanges() []string {
 	return m.changes.ToString()
 }
 
-type MutatorAddress struct {
-	inner   *Address
+// MutatorSupplier mutates the Supplier object.
+type MutatorSupplier struct {
+	inner   *Supplier
 	changes changes.Logger
 }
 
-func NewMutatorAddress(obj *Address, changes changes.Logger) *MutatorAddress {
-	return &MutatorAddress{
+// NewMutatorSupplier creates a new mutator for the Supplier object.
+func NewMutatorSupplier(
+	obj *Supplier,
+	options ...func(*MutatorSupplier),
+) *MutatorSupplier {
+	m := &MutatorSupplier{
 		inner:   obj,
-		changes: changes,
+		changes: changes.NewDefaultLogger(changes.PrefixEmpty),
+	}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
+}
+
+// WithChangeLoggerSupplier sets the change logger for the Supplier mutator.
+func WithChangeLoggerSupplier(logger changes.Logger) func(*MutatorSupplier) {
+	return func(m *MutatorSupplier) {
+		m.changes = logger
 	}
 }
 
-type MutatorVat struct {
-	inner   *Vat
+// FormatChanges returns the changes that were made to the object as strings
+func (m *MutatorSupplier) FormatChanges() []string {
+	return m.changes.ToString()
+}
+
+// MutatorPage mutates the Page[T] object.
+type MutatorPage[T any] struct {
+	inner   *Page[T]
 	changes changes.Logger
 }
 
-func NewMutatorVat(obj *Vat, changes changes.Logger) *MutatorVat {
-	return &MutatorVat{
+// NewMutatorPage creates a new mutator for the Page[T] object.
+func NewMutatorPage[T any](
+	obj *Page[T],
+	options ...func(*MutatorPage[T]),
+) *MutatorPage[T] {
+	m := &MutatorPage[T]{
+		inner:   obj,
+		changes: changes.NewDefaultLogger(changes.PrefixEmpty),
+	}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
+}
+
+// WithChangeLoggerPage sets the change logger for the Page[T] mutator.
+func WithChangeLoggerPage[T any](logger changes.Logger) func(*MutatorPage[T]) {
+	return func(m *MutatorPage[T]) {
+		m.changes = logger
+	}
+}
+
+// FormatChanges returns the changes that were made to the object as strings
+func (m *MutatorPage[T]) FormatChanges() []string {
+	return m.changes.ToString()
+}
+
+// MutatorDepartment mutates the Department object.
+type MutatorDepartment struct {
+	inner   *Department
+	changes changes.Logger
+}
+
+// NewMutatorDepartment creates a new mutator for the Department object.
+func NewMutatorDepartment(
+	obj *Department,
+	options ...func(*MutatorDepartment),
+) *MutatorDepartment {
+	m := &MutatorDepartment{
+		inner:   obj,
+		changes: changes.NewDefaultLogger(changes.PrefixEmpty),
+	}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
+}
+
+// WithChangeLoggerDepartment sets the change logger for the Department mutator.
+func WithChangeLoggerDepartment(logger changes.Logger) func(*MutatorDepartment) {
+	return func(m *MutatorDepartment) {
+		m.changes = logger
+	}
+}
+
+// FormatChanges returns the changes that were made to the object as strings
+func (m *MutatorDepartment) FormatChanges() []string {
+	return m.changes.ToString()
+}
+
+// MutatorOrder mutates the Order object.
+type MutatorOrder struct {
+	inner   *Order
+	changes changes.Logger
+}
+
+// NewMutatorOrder creates a new mutator for the Order object.
+func NewMutatorOrder(
+	obj *Order,
+	options ...func(*MutatorOrder),
+) *MutatorOrder {
+	m := &MutatorOrder{
+		inner:   obj,
+		changes: changes.NewDefaultLogger(changes.PrefixEmpty),
+	}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
+}
+
+// WithChangeLoggerOrder sets the change logger for the Order mutator.
+func WithChangeLoggerOrder(logger changes.Logger) func(*MutatorOrder) {
+	return func(m *MutatorOrder) {
+		m.changes = logger
+	}
+}
+
+// FormatChanges returns the changes that were made to the object as strings
+func (m *MutatorOrder) FormatChanges() []string {
+	return m.changes.ToString()
+}
+
+type MutatorAudit struct {
+	inner   *Audit
+	changes changes.Logger
+}
+
+func NewMutatorAudit(obj *Audit, changes changes.Logger) *MutatorAudit {
+	return &MutatorAudit{
 		inner:   obj,
 		changes: changes,
 	}
@@ -94,814 +222,3070 @@ func NewMutatorProject(obj *Project, changes changes.Logger) *MutatorProject {
 	}
 }
 
+type MutatorAddress struct {
+	inner   *Address
+	changes changes.Logger
+}
 
-const (
-	MutationPrefixAddress changes.FieldName = "Address"
-	MutationPrefixEmployees changes.FieldName = "Employees"
-	MutationPrefixEmployeesProjects changes.FieldName = "Projects"
-	MutationPrefixNicknames changes.FieldName = "Nicknames"
-	MutationPrefixVat changes.FieldName = "Vat"
-)
-
-// SetName mutates the Name of the Acme object
-func (m *MutatorAcme) SetName(value string) bool {
-	if m.inner.Name == value {
-		return false
+func NewMutatorAddress(obj *Address, changes changes.Logger) *MutatorAddress {
+	return &MutatorAddress{
+		inner:   obj,
+		changes: changes,
 	}
+}
 
-	operation := changes.OperationUpdated
-	if reflect.ValueOf(m.inner.Name).IsZero() {
-		operation = changes.OperationSet
-	} else if reflect.ValueOf(value).IsZero() {
-		operation = changes.OperationCleared
-	}
+type MutatorVat struct {
+	inner   *Vat
+	changes changes.Logger
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Name",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Name),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.Name = value
+func NewMutatorVat(obj *Vat, changes changes.Logger) *MutatorVat {
+	return &MutatorVat{
+		inner:   obj,
+		changes: changes,
+	}
+}
 
-	return true
+type MutatorBillingAccount struct {
+	inner   *billing.Account
+	changes changes.Logger
 }
 
-// SetYearOfBirth mutates the YearOfBirth of the Acme object
-func (m *MutatorAcme) SetYearOfBirth(value int) bool {
-	if m.inner.YearOfBirth == value {
-		return false
+func NewMutatorBillingAccount(obj *billing.Account, changes changes.Logger) *MutatorBillingAccount {
+	return &MutatorBillingAccount{
+		inner:   obj,
+		changes: changes,
 	}
+}
 
-	operation := changes.OperationUpdated
-	if reflect.ValueOf(m.inner.YearOfBirth).IsZero() {
-		operation = changes.OperationSet
-	} else if reflect.ValueOf(value).IsZero() {
-		operation = changes.OperationCleared
-	}
+type MutatorBillingLimits struct {
+	inner   *billing.Limits
+	changes changes.Logger
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "YearOfBirth",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.YearOfBirth),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.YearOfBirth = value
+func NewMutatorBillingLimits(obj *billing.Limits, changes changes.Logger) *MutatorBillingLimits {
+	return &MutatorBillingLimits{
+		inner:   obj,
+		changes: changes,
+	}
+}
 
-	return true
+type MutatorTag struct {
+	inner   *Tag
+	changes changes.Logger
 }
 
-// SetName mutates the Name of the Employee object
-func (m *MutatorEmployee) SetName(value string) bool {
-	if m.inner.Name == value {
-		return false
+func NewMutatorTag(obj *Tag, changes changes.Logger) *MutatorTag {
+	return &MutatorTag{
+		inner:   obj,
+		changes: changes,
 	}
+}
+
+type MutatorPagePtrEmployee struct {
+	inner   *Page[*Employee]
+	changes changes.Logger
+}
 
-	operation := changes.OperationUpdated
-	if reflect.ValueOf(m.inner.Name).IsZero() {
-		operation = changes.OperationSet
-	} else if reflect.ValueOf(value).IsZero() {
-		operation = changes.OperationCleared
+func NewMutatorPagePtrEmployee(obj *Page[*Employee], changes changes.Logger) *MutatorPagePtrEmployee {
+	return &MutatorPagePtrEmployee{
+		inner:   obj,
+		changes: changes,
 	}
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Name",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Name),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.Name = value
+type MutatorCard struct {
+	inner   *Card
+	changes changes.Logger
+}
 
-	return true
+func NewMutatorCard(obj *Card, changes changes.Logger) *MutatorCard {
+	return &MutatorCard{
+		inner:   obj,
+		changes: changes,
+	}
 }
 
-// SetPosition mutates the Position of the Employee object
-func (m *MutatorEmployee) SetPosition(value string) bool {
-	if m.inner.Position == value {
-		return false
+type MutatorWire struct {
+	inner   *Wire
+	changes changes.Logger
+}
+
+func NewMutatorWire(obj *Wire, changes changes.Logger) *MutatorWire {
+	return &MutatorWire{
+		inner:   obj,
+		changes: changes,
 	}
+}
+
+type MutatorM2Metrics struct {
+	inner   *m2.Metrics
+	changes changes.Logger
+}
 
-	operation := changes.OperationUpdated
-	if reflect.ValueOf(m.inner.Position).IsZero() {
-		operation = changes.OperationSet
-	} else if reflect.ValueOf(value).IsZero() {
-		operation = changes.OperationCleared
+func NewMutatorM2Metrics(obj *m2.Metrics, changes changes.Logger) *MutatorM2Metrics {
+	return &MutatorM2Metrics{
+		inner:   obj,
+		changes: changes,
 	}
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Position",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Position),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.Position = value
+type MutatorTeam struct {
+	inner   *Team
+	changes changes.Logger
+}
 
-	return true
+func NewMutatorTeam(obj *Team, changes changes.Logger) *MutatorTeam {
+	return &MutatorTeam{
+		inner:   obj,
+		changes: changes,
+	}
 }
 
-// SetWage mutates the Wage of the Employee object
-func (m *MutatorEmployee) SetWage(value int) bool {
-	if m.inner.Wage == value {
-		return false
+type MutatorItem struct {
+	inner   *Item
+	changes changes.Logger
+}
+
+func NewMutatorItem(obj *Item, changes changes.Logger) *MutatorItem {
+	return &MutatorItem{
+		inner:   obj,
+		changes: changes,
 	}
+}
+
+type MutatorOrderLine struct {
+	inner   *OrderLine
+	changes changes.Logger
+}
 
-	operation := changes.OperationUpdated
-	if reflect.ValueOf(m.inner.Wage).IsZero() {
-		operation = changes.OperationSet
-	} else if reflect.ValueOf(value).IsZero() {
-		operation = changes.OperationCleared
+func NewMutatorOrderLine(obj *OrderLine, changes changes.Logger) *MutatorOrderLine {
+	return &MutatorOrderLine{
+		inner:   obj,
+		changes: changes,
 	}
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Wage",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Wage),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.Wage = value
+const (
+	MutationPrefixAcmeAddress              changes.FieldName = "Address"
+	MutationPrefixAcmeAudit                changes.FieldName = "Audit"
+	MutationPrefixAcmeBilling              changes.FieldName = "Billing"
+	MutationPrefixAcmeEmployees            changes.FieldName = "Employees"
+	MutationPrefixAcmeHires                changes.FieldName = "Hires"
+	MutationPrefixAcmeMetrics              changes.FieldName = "Metrics"
+	MutationPrefixAcmeNicknames            changes.FieldName = "Nicknames"
+	MutationPrefixAcmePayment              changes.FieldName = "Payment"
+	MutationPrefixAcmeRegions              changes.FieldName = "Regions"
+	MutationPrefixAcmeShifts               changes.FieldName = "Shifts"
+	MutationPrefixAcmeTags                 changes.FieldName = "Tags"
+	MutationPrefixAcmeVat                  changes.FieldName = "Vat"
+	MutationPrefixBillingAccountLimits     changes.FieldName = "Limits"
+	MutationPrefixDepartmentHead           changes.FieldName = "Head"
+	MutationPrefixDepartmentSubdepartments changes.FieldName = "Subdepartments"
+	MutationPrefixDepartmentTeams          changes.FieldName = "Teams"
+	MutationPrefixEmployeeAudit            changes.FieldName = "Audit"
+	MutationPrefixEmployeeProjects         changes.FieldName = "Projects"
+	MutationPrefixOrderLine                changes.FieldName = "Line"
+	MutationPrefixOrderLine_Items          changes.FieldName = "Items"
+	MutationPrefixOrder_LineItems          changes.FieldName = "LineItems"
+	MutationPrefixPageNext                 changes.FieldName = "Next"
+	MutationPrefixPagePtrEmployeeItems     changes.FieldName = "Items"
+	MutationPrefixPagePtrEmployeeLast      changes.FieldName = "Last"
+	MutationPrefixPagePtrEmployeeNext      changes.FieldName = "Next"
+	MutationPrefixSupplierClients          changes.FieldName = "Clients"
+	MutationPrefixSupplierContact          changes.FieldName = "Main contact"
+	MutationPrefixTeamMembers              changes.FieldName = "Members"
+	MutationPrefixTeamSpinoff              changes.FieldName = "Spinoff"
+)
 
-	return true
+// SetName mutates the Name of the Audit object
+func (m *MutatorAudit) SetName(value string) bool {
+	return changes.Set(m.changes, "Name", &m.inner.Name, value, m.inner.Name == value)
 }
 
-// SetJoinedAt mutates the JoinedAt of the Employee object
-func (m *MutatorEmployee) SetJoinedAt(value time.Time) bool {
-	if m.inner.JoinedAt == value {
-		return false
-	}
+// SetCreatedBy mutates the CreatedBy of the Audit object
+func (m *MutatorAudit) SetCreatedBy(value string) bool {
+	return changes.Set(m.changes, "CreatedBy", &m.inner.CreatedBy, value, m.inner.CreatedBy == value)
+}
+
+// SetRevision mutates the Revision of the Audit object
+func (m *MutatorAudit) SetRevision(value int) bool {
+	return changes.Set(m.changes, "Revision", &m.inner.Revision, value, m.inner.Revision == value)
+}
+
+// SetAudit sets Audit of the Acme object
+func (m *MutatorAcme) SetAudit(value *Audit) bool {
+	return changes.SetObject(m.changes, "Audit", &m.inner.Audit, value)
+}
 
-	operation := changes.OperationUpdated
-	if reflect.ValueOf(m.inner.JoinedAt).IsZero() {
-		operation = changes.OperationSet
-	} else if reflect.ValueOf(value).IsZero() {
-		operation = changes.OperationCleared
+// Audit returns a mutator for Audit of the Acme object.
+func (m *MutatorAcme) Audit() AuditMutator {
+	prefix := changes.NewPrefix(MutationPrefixAcmeAudit)
+
+	return &MutatorAudit{
+		inner:   &m.inner.Audit,
+		changes: changes.NewChainedLogger(prefix, m.changes),
 	}
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "JoinedAt",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.JoinedAt),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.JoinedAt = value
+// SetCreatedBy mutates the CreatedBy of the Acme object, promoted from Audit
+func (m *MutatorAcme) SetCreatedBy(value string) bool {
+	return m.Audit().SetCreatedBy(value)
+}
 
-	return true
+// SetRevision mutates the Revision of the Acme object, promoted from Audit
+func (m *MutatorAcme) SetRevision(value int) bool {
+	return m.Audit().SetRevision(value)
 }
 
-// SetName mutates the Name of the Project object
-func (m *MutatorProject) SetName(value string) bool {
-	if m.inner.Name == value {
+// SetID mutates the ID of the Acme object
+func (m *MutatorAcme) SetID(value string) bool {
+	if !changes.IsZero(&m.inner.ID) {
 		return false
 	}
 
-	operation := changes.OperationUpdated
-	if reflect.ValueOf(m.inner.Name).IsZero() {
-		operation = changes.OperationSet
-	} else if reflect.ValueOf(value).IsZero() {
-		operation = changes.OperationCleared
-	}
+	return changes.Set(m.changes, "ID", &m.inner.ID, value, m.inner.ID == value)
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Name",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Name),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.Name = value
+// SetName mutates the Name of the Acme object
+func (m *MutatorAcme) SetName(value string) bool {
+	return changes.Set(m.changes, "Name", &m.inner.Name, value, m.inner.Name == value)
+}
 
-	return true
+// SetYearOfBirth mutates the YearOfBirth of the Acme object
+func (m *MutatorAcme) SetYearOfBirth(value int) bool {
+	return changes.Set(m.changes, "YearOfBirth", &m.inner.YearOfBirth, value, m.inner.YearOfBirth == value)
 }
 
-// SetValue mutates the Value of the Project object
-func (m *MutatorProject) SetValue(value int) bool {
-	if m.inner.Value == value {
-		return false
-	}
+// SetName mutates the Name of the Employee object
+func (m *MutatorEmployee) SetName(value string) bool {
+	return changes.Set(m.changes, "Name", &m.inner.Name, value, m.inner.Name == value)
+}
 
-	operation := changes.OperationUpdated
-	if reflect.ValueOf(m.inner.Value).IsZero() {
-		operation = changes.OperationSet
-	} else if reflect.ValueOf(value).IsZero() {
-		operation = changes.OperationCleared
-	}
+// SetPosition mutates the Position of the Employee object
+func (m *MutatorEmployee) SetPosition(value string) bool {
+	return changes.Set(m.changes, "Position", &m.inner.Position, value, m.inner.Position == value)
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Value",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Value),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.Value = value
+// SetWage mutates the Wage of the Employee object
+func (m *MutatorEmployee) SetWage(value int) bool {
+	return changes.Set(m.changes, "Wage", &m.inner.Wage, value, m.inner.Wage == value)
+}
 
-	return true
+// SetJoinedAt mutates the JoinedAt of the Employee object
+func (m *MutatorEmployee) SetJoinedAt(value time.Time) bool {
+	return changes.Set(m.changes, "JoinedAt", &m.inner.JoinedAt, value, m.inner.JoinedAt.Equal(value))
+}
+
+// SetName mutates the Name of the Project object
+func (m *MutatorProject) SetName(value string) bool {
+	return changes.Set(m.changes, "Name", &m.inner.Name, value, m.inner.Name == value)
+}
+
+// SetValue mutates the Value of the Project object
+func (m *MutatorProject) SetValue(value int) bool {
+	return changes.Set(m.changes, "Value", &m.inner.Value, value, m.inner.Value == value)
 }
 
 // SetStartedAt mutates the StartedAt of the Project object
 func (m *MutatorProject) SetStartedAt(value time.Time) bool {
-	if m.inner.StartedAt == value {
-		return false
-	}
-
-	operation := changes.OperationUpdated
-	if reflect.ValueOf(m.inner.StartedAt).IsZero() {
-		operation = changes.OperationSet
-	} else if reflect.ValueOf(value).IsZero() {
-		operation = changes.OperationCleared
-	}
+	return changes.Set(m.changes, "StartedAt", &m.inner.StartedAt, value, m.inner.StartedAt.Equal(value))
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "StartedAt",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.StartedAt),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.StartedAt = value
+// SetFinishedAt mutates the FinishedAt of the Project object
+func (m *MutatorProject) SetFinishedAt(value time.Time) bool {
+	return changes.Set(m.changes, "FinishedAt", &m.inner.FinishedAt, value, m.inner.FinishedAt.Equal(value))
+}
+
+// SetSeqID mutates the SeqID of the Project object
+func (m *MutatorProject) SetSeqID(value []byte) bool {
+	return changes.SetBytes(m.changes, "SeqID", &m.inner.SeqID, value)
+}
+
+// SetProjects sets Projects of the Employee object
+func (m *MutatorEmployee) SetProjects(value []Project) bool {
+	return changes.SetSlice(m.changes, "Projects", &m.inner.Projects, value)
+}
+
+// AppendProjects appends a Projects element of the Employee object.
+func (m *MutatorEmployee) AppendProjects(value ...Project) {
+	changes.Append(m.changes, "Projects", &m.inner.Projects, value...)
+}
+
+// RemoveProjects removes a Projects element of the Employee object.
+func (m *MutatorEmployee) RemoveProjects(index int) {
+	changes.RemoveIndex(m.changes, "Projects", &m.inner.Projects, index)
+}
+
+// ProjectsAt returns a mutator for Projects element at index of the Employee object.
+func (m *MutatorEmployee) ProjectsAt(index int) ProjectMutator {
+	object := &m.inner.Projects[index]
+
+	prefix := changes.NewPrefixWithKey(MutationPrefixEmployeeProjects, changes.IntoKey(object))
+
+	return &MutatorProject{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// SetAudit sets Audit of the Employee object
+func (m *MutatorEmployee) SetAudit(value *Audit) bool {
+	return changes.SetPointer(m.changes, "Audit", &m.inner.Audit, value, m.inner.Audit == value)
+}
+
+// Audit returns a mutator for Audit of the Employee object.
+// If the field is nil, it will be initialized to a new Audit object.
+func (m *MutatorEmployee) Audit() AuditMutator {
+
+	if m.inner.Audit == nil {
+		m.inner.Audit = &Audit{}
+	}
+
+	prefix := changes.NewPrefix(MutationPrefixEmployeeAudit)
+
+	return &MutatorAudit{
+		inner:   m.inner.Audit,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// SetCreatedBy mutates the CreatedBy of the Employee object, promoted from Audit
+func (m *MutatorEmployee) SetCreatedBy(value string) bool {
+	return m.Audit().SetCreatedBy(value)
+}
+
+// SetRevision mutates the Revision of the Employee object, promoted from Audit
+func (m *MutatorEmployee) SetRevision(value int) bool {
+	return m.Audit().SetRevision(value)
+}
+
+// SetEmployees sets Employees of the Acme object
+func (m *MutatorAcme) SetEmployees(value []*Employee) bool {
+	return changes.SetSlice(m.changes, "Employees", &m.inner.Employees, value)
+}
+
+// AppendEmployees appends a Employees element of the Acme object.
+func (m *MutatorAcme) AppendEmployees(value ...*Employee) {
+	changes.Append(m.changes, "Employees", &m.inner.Employees, value...)
+}
+
+// RemoveEmployees removes a Employees element of the Acme object.
+func (m *MutatorAcme) RemoveEmployees(index int) {
+	changes.RemoveIndex(m.changes, "Employees", &m.inner.Employees, index)
+}
+
+// EmployeesAt returns a mutator for Employees element at index of the Acme object.
+func (m *MutatorAcme) EmployeesAt(index int) EmployeeMutator {
+	object := m.inner.Employees[index]
+
+	prefix := changes.NewPrefixWithKey(MutationPrefixAcmeEmployees, changes.IntoKey(object))
+
+	return &MutatorEmployee{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// EmployeesByPtr returns a mutator for Employees element given by a pointer of type Acme.
+func (m *MutatorAcme) EmployeesByPtr(ptr *Employee) EmployeeMutator {
+	for i, item := range m.inner.Employees {
+		if item == ptr {
+			return m.EmployeesAt(i)
+		}
+	}
+	return nil
+}
+
+// SetStreet mutates the Street of the Address object
+func (m *MutatorAddress) SetStreet(value string) bool {
+	return changes.Set(m.changes, "Street", &m.inner.Street, value, m.inner.Street == value)
+}
+
+// SetNumber mutates the Number of the Address object
+func (m *MutatorAddress) SetNumber(value int) bool {
+	return changes.Set(m.changes, "Number", &m.inner.Number, value, m.inner.Number == value)
+}
+
+// SetCity mutates the City of the Address object
+func (m *MutatorAddress) SetCity(value string) bool {
+	return changes.Set(m.changes, "City", &m.inner.City, value, m.inner.City == value)
+}
+
+// SetZip mutates the Zip of the Address object
+func (m *MutatorAddress) SetZip(value int) bool {
+	return changes.Set(m.changes, "Postal code", &m.inner.Zip, value, m.inner.Zip == value)
+}
+
+// SetLocation sets Location of the Address object
+func (m *MutatorAddress) SetLocation(value *string) bool {
+	return changes.SetPointer(m.changes, "Location", &m.inner.Location, value, m.inner.Location == value)
+}
+
+// SetAddress sets Address of the Acme object
+func (m *MutatorAcme) SetAddress(value *Address) bool {
+	return changes.SetPointer(m.changes, "Address", &m.inner.Address, value, m.inner.Address == value)
+}
+
+// Address returns a mutator for Address of the Acme object.
+// If the field is nil, it will be initialized to a new Address object.
+func (m *MutatorAcme) Address() AddressMutator {
+
+	if m.inner.Address == nil {
+		m.inner.Address = &Address{}
+	}
+
+	prefix := changes.NewPrefix(MutationPrefixAcmeAddress)
+
+	return &MutatorAddress{
+		inner:   m.inner.Address,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// SetNumber mutates the Number of the Vat object
+func (m *MutatorVat) SetNumber(value string) bool {
+	return changes.Set(m.changes, "Number", &m.inner.Number, value, m.inner.Number == value)
+}
+
+// SetType mutates the Type of the Vat object
+func (m *MutatorVat) SetType(value string) bool {
+	return changes.Set(m.changes, "Type", &m.inner.Type, value, m.inner.Type == value)
+}
+
+// Vat returns a mutator for Vat of the Acme object.
+func (m *MutatorAcme) Vat() VatMutator {
+	prefix := changes.NewPrefix(MutationPrefixAcmeVat)
+
+	return &MutatorVat{
+		inner:   &m.inner.Vat,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// SetNicknames sets Nicknames of the Acme object
+func (m *MutatorAcme) SetNicknames(value map[string]*Employee) bool {
+	return changes.SetMap(m.changes, "Nicknames", &m.inner.Nicknames, value)
+}
+
+// InsertNicknames inserts a Nicknames map element of the Acme object.
+func (m *MutatorAcme) InsertNicknames(
+	key string,
+	value *Employee,
+) bool {
+	return changes.Insert(m.changes, "Nicknames", &m.inner.Nicknames, key, value, func(currentValue, value *Employee) bool {
+		return currentValue == value
+	})
+}
+
+// RemoveNicknames removes a Nicknames map element of the Acme object.
+func (m *MutatorAcme) RemoveNicknames(key string) bool {
+	return changes.RemoveKey(m.changes, "Nicknames", &m.inner.Nicknames, key)
+}
+
+// NicknamesWithKey returns a mutator for Nicknames map element Acme object with given key.
+func (m *MutatorAcme) NicknamesWithKey(key string) EmployeeMutator {
+	object := m.inner.Nicknames[key]
+
+	prefix := changes.NewPrefixWithKey(MutationPrefixAcmeNicknames, changes.IntoKey(object))
+
+	return &MutatorEmployee{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// SetEquity sets Equity of the Acme object
+func (m *MutatorAcme) SetEquity(value map[*Employee]int) bool {
+	return changes.SetMap(m.changes, "Equity", &m.inner.Equity, value)
+}
+
+// InsertEquity inserts a Equity map element of the Acme object.
+func (m *MutatorAcme) InsertEquity(
+	key *Employee,
+	value int,
+) bool {
+	return changes.Insert(m.changes, "Equity", &m.inner.Equity, key, value, func(currentValue, value int) bool {
+		return currentValue == value
+	})
+}
+
+// RemoveEquity removes a Equity map element of the Acme object.
+func (m *MutatorAcme) RemoveEquity(key *Employee) bool {
+	return changes.RemoveKey(m.changes, "Equity", &m.inner.Equity, key)
+}
+
+// SetIBAN mutates the IBAN of the billing.Account object
+func (m *MutatorBillingAccount) SetIBAN(value string) bool {
+	return changes.Set(m.changes, "IBAN", &m.inner.IBAN, value, m.inner.IBAN == value)
+}
+
+// SetHolder mutates the Holder of the billing.Account object
+func (m *MutatorBillingAccount) SetHolder(value string) bool {
+	return changes.Set(m.changes, "Holder", &m.inner.Holder, value, m.inner.Holder == value)
+}
+
+// SetDaily mutates the Daily of the billing.Limits object
+func (m *MutatorBillingLimits) SetDaily(value int) bool {
+	return changes.Set(m.changes, "Daily", &m.inner.Daily, value, m.inner.Daily == value)
+}
+
+// SetMonthly mutates the Monthly of the billing.Limits object
+func (m *MutatorBillingLimits) SetMonthly(value int) bool {
+	return changes.Set(m.changes, "Monthly", &m.inner.Monthly, value, m.inner.Monthly == value)
+}
+
+// SetLimits sets Limits of the billing.Account object
+func (m *MutatorBillingAccount) SetLimits(value *billing.Limits) bool {
+	return changes.SetPointer(m.changes, "Limits", &m.inner.Limits, value, m.inner.Limits == value)
+}
+
+// Limits returns a mutator for Limits of the billing.Account object.
+// If the field is nil, it will be initialized to a new billing.Limits object.
+func (m *MutatorBillingAccount) Limits() BillingLimitsMutator {
+
+	if m.inner.Limits == nil {
+		m.inner.Limits = &billing.Limits{}
+	}
+
+	prefix := changes.NewPrefix(MutationPrefixBillingAccountLimits)
+
+	return &MutatorBillingLimits{
+		inner:   m.inner.Limits,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// enumNamesBillingCurrency maps the declared billing.Currency constants to their names.
+var enumNamesBillingCurrency = map[billing.Currency]string{
+	billing.CurrencyEUR: "CurrencyEUR",
+	billing.CurrencyUSD: "CurrencyUSD",
+	billing.CurrencyGBP: "CurrencyGBP",
+}
+
+// SetCurrency mutates the Currency of the billing.Account object,
+// values other than the declared billing.Currency constants are rejected.
+func (m *MutatorBillingAccount) SetCurrency(value billing.Currency) bool {
+	return changes.SetEnum(m.changes, "Currency", &m.inner.Currency, value, enumNamesBillingCurrency)
+}
+
+// SetFunding mutates the Funding of the billing.Account object
+func (m *MutatorBillingAccount) SetFunding(value billing.Funding) bool {
+	return changes.Set(m.changes, "Funding", &m.inner.Funding, value, m.inner.Funding == value)
+}
+
+// SetBilling sets Billing of the Acme object
+func (m *MutatorAcme) SetBilling(value *billing.Account) bool {
+	return changes.SetObject(m.changes, "Billing", &m.inner.Billing, value)
+}
+
+// Billing returns a mutator for Billing of the Acme object.
+func (m *MutatorAcme) Billing() BillingAccountMutator {
+	prefix := changes.NewPrefix(MutationPrefixAcmeBilling)
+
+	return &MutatorBillingAccount{
+		inner:   &m.inner.Billing,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// MutatorSliceTag mutates a []Tag element of a slice or map.
+type MutatorSliceTag struct {
+	get     func() []Tag
+	set     func([]Tag)
+	changes changes.Logger
+}
+
+// Set sets the []Tag element.
+func (m *MutatorSliceTag) Set(value []Tag) bool {
+	current := m.get()
+	if !changes.SetSlice(m.changes, "", &current, value) {
+		return false
+	}
+
+	m.set(current)
+
+	return true
+}
+
+// Append appends elements to the []Tag element.
+func (m *MutatorSliceTag) Append(value ...Tag) {
+	current := m.get()
+	changes.Append(m.changes, "", &current, value...)
+	m.set(current)
+}
+
+// Remove removes the element at index of the []Tag element.
+func (m *MutatorSliceTag) Remove(index int) {
+	current := m.get()
+	changes.RemoveIndex(m.changes, "", &current, index)
+	m.set(current)
+}
+
+// SetName mutates the Name of the Tag object
+func (m *MutatorTag) SetName(value string) bool {
+	return changes.Set(m.changes, "Name", &m.inner.Name, value, m.inner.Name == value)
+}
+
+// SetColor mutates the Color of the Tag object
+func (m *MutatorTag) SetColor(value string) bool {
+	return changes.Set(m.changes, "Colour", &m.inner.Color, value, m.inner.Color == value)
+}
+
+// At returns a mutator for the element of the []Tag element with given index.
+func (m *MutatorSliceTag) At(index int) TagMutator {
+	object := &m.get()[index]
+
+	prefix := changes.NewPrefixWithKey(changes.FieldNameEmpty, changes.IntoKey(index))
+
+	return &MutatorTag{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// SetTags sets Tags of the Acme object
+func (m *MutatorAcme) SetTags(value map[string][]Tag) bool {
+	return changes.SetMap(m.changes, "Tags", &m.inner.Tags, value)
+}
+
+// InsertTags inserts a Tags map element of the Acme object.
+func (m *MutatorAcme) InsertTags(
+	key string,
+	value []Tag,
+) bool {
+	return changes.Insert(m.changes, "Tags", &m.inner.Tags, key, value, func(currentValue, value []Tag) bool {
+		return reflect.DeepEqual(currentValue, value)
+	})
+}
+
+// RemoveTags removes a Tags map element of the Acme object.
+func (m *MutatorAcme) RemoveTags(key string) bool {
+	return changes.RemoveKey(m.changes, "Tags", &m.inner.Tags, key)
+}
+
+// TagsWithKey returns a mutator for Tags map element of the Acme object with given key.
+func (m *MutatorAcme) TagsWithKey(key string) SliceTagMutator {
+	prefix := changes.NewPrefixWithKey(MutationPrefixAcmeTags, changes.IntoKey(key))
+
+	return &MutatorSliceTag{
+		get: func() []Tag {
+			return m.inner.Tags[key]
+		},
+		set: func(value []Tag) {
+			if m.inner.Tags == nil {
+				m.inner.Tags = make(map[string][]Tag)
+			}
+			m.inner.Tags[key] = value
+		},
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// MutatorSliceString mutates a []string element of a slice or map.
+type MutatorSliceString struct {
+	get     func() []string
+	set     func([]string)
+	changes changes.Logger
+}
+
+// Set sets the []string element.
+func (m *MutatorSliceString) Set(value []string) bool {
+	current := m.get()
+	if !changes.SetSlice(m.changes, "", &current, value) {
+		return false
+	}
+
+	m.set(current)
+
+	return true
+}
+
+// Append appends elements to the []string element.
+func (m *MutatorSliceString) Append(value ...string) {
+	current := m.get()
+	changes.Append(m.changes, "", &current, value...)
+	m.set(current)
+}
+
+// Remove removes the element at index of the []string element.
+func (m *MutatorSliceString) Remove(index int) {
+	current := m.get()
+	changes.RemoveIndex(m.changes, "", &current, index)
+	m.set(current)
+}
+
+// SetShifts sets Shifts of the Acme object
+func (m *MutatorAcme) SetShifts(value [][]string) bool {
+	return changes.SetSlice(m.changes, "Shifts", &m.inner.Shifts, value)
+}
+
+// AppendShifts appends a Shifts element of the Acme object.
+func (m *MutatorAcme) AppendShifts(value ...[]string) {
+	changes.Append(m.changes, "Shifts", &m.inner.Shifts, value...)
+}
+
+// RemoveShifts removes a Shifts element of the Acme object.
+func (m *MutatorAcme) RemoveShifts(index int) {
+	changes.RemoveIndex(m.changes, "Shifts", &m.inner.Shifts, index)
+}
+
+// ShiftsAt returns a mutator for Shifts element at index of the Acme object.
+func (m *MutatorAcme) ShiftsAt(index int) SliceStringMutator {
+	prefix := changes.NewPrefixWithKey(MutationPrefixAcmeShifts, changes.IntoKey(index))
+
+	return &MutatorSliceString{
+		get: func() []string {
+			return m.inner.Shifts[index]
+		},
+		set: func(value []string) {
+			m.inner.Shifts[index] = value
+		},
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// MutatorMapIntString mutates a map[int]string element of a slice or map.
+type MutatorMapIntString struct {
+	get     func() map[int]string
+	set     func(map[int]string)
+	changes changes.Logger
+}
+
+// Set sets the map[int]string element.
+func (m *MutatorMapIntString) Set(value map[int]string) bool {
+	current := m.get()
+	if !changes.SetMap(m.changes, "", &current, value) {
+		return false
+	}
+
+	m.set(current)
+
+	return true
+}
+
+// Insert inserts an element into the map[int]string element.
+func (m *MutatorMapIntString) Insert(key int, value string) bool {
+	current := m.get()
+	if !changes.Insert(m.changes, "", &current, key, value, func(currentValue, value string) bool {
+		return currentValue == value
+	}) {
+		return false
+	}
+
+	m.set(current)
+
+	return true
+}
+
+// Remove removes an element from the map[int]string element.
+func (m *MutatorMapIntString) Remove(key int) bool {
+	current := m.get()
+	return changes.RemoveKey(m.changes, "", &current, key)
+}
+
+// SetRegions sets Regions of the Acme object
+func (m *MutatorAcme) SetRegions(value map[string]map[int]string) bool {
+	return changes.SetMap(m.changes, "Regions", &m.inner.Regions, value)
+}
+
+// InsertRegions inserts a Regions map element of the Acme object.
+func (m *MutatorAcme) InsertRegions(
+	key string,
+	value map[int]string,
+) bool {
+	return changes.Insert(m.changes, "Regions", &m.inner.Regions, key, value, func(currentValue, value map[int]string) bool {
+		return reflect.DeepEqual(currentValue, value)
+	})
+}
+
+// RemoveRegions removes a Regions map element of the Acme object.
+func (m *MutatorAcme) RemoveRegions(key string) bool {
+	return changes.RemoveKey(m.changes, "Regions", &m.inner.Regions, key)
+}
+
+// RegionsWithKey returns a mutator for Regions map element of the Acme object with given key.
+func (m *MutatorAcme) RegionsWithKey(key string) MapIntStringMutator {
+	prefix := changes.NewPrefixWithKey(MutationPrefixAcmeRegions, changes.IntoKey(key))
+
+	return &MutatorMapIntString{
+		get: func() map[int]string {
+			return m.inner.Regions[key]
+		},
+		set: func(value map[int]string) {
+			if m.inner.Regions == nil {
+				m.inner.Regions = make(map[string]map[int]string)
+			}
+			m.inner.Regions[key] = value
+		},
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// SetItems sets Items of the Page[*Employee] object
+func (m *MutatorPagePtrEmployee) SetItems(value []*Employee) bool {
+	return changes.SetSlice(m.changes, "Items", &m.inner.Items, value)
+}
+
+// AppendItems appends a Items element of the Page[*Employee] object.
+func (m *MutatorPagePtrEmployee) AppendItems(value ...*Employee) {
+	changes.Append(m.changes, "Items", &m.inner.Items, value...)
+}
+
+// RemoveItems removes a Items element of the Page[*Employee] object.
+func (m *MutatorPagePtrEmployee) RemoveItems(index int) {
+	changes.RemoveIndex(m.changes, "Items", &m.inner.Items, index)
+}
+
+// ItemsAt returns a mutator for Items element at index of the Page[*Employee] object.
+func (m *MutatorPagePtrEmployee) ItemsAt(index int) EmployeeMutator {
+	object := m.inner.Items[index]
+
+	prefix := changes.NewPrefixWithKey(MutationPrefixPagePtrEmployeeItems, changes.IntoKey(object))
+
+	return &MutatorEmployee{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// ItemsByPtr returns a mutator for Items element given by a pointer of type Page[*Employee].
+func (m *MutatorPagePtrEmployee) ItemsByPtr(ptr *Employee) EmployeeMutator {
+	for i, item := range m.inner.Items {
+		if item == ptr {
+			return m.ItemsAt(i)
+		}
+	}
+	return nil
+}
+
+// SetCursor mutates the Cursor of the Page[*Employee] object
+func (m *MutatorPagePtrEmployee) SetCursor(value string) bool {
+	return changes.Set(m.changes, "Cursor", &m.inner.Cursor, value, m.inner.Cursor == value)
+}
+
+// SetLast sets Last of the Page[*Employee] object
+func (m *MutatorPagePtrEmployee) SetLast(value *Employee) bool {
+	return changes.SetPointer(m.changes, "Last", &m.inner.Last, value, m.inner.Last == value)
+}
+
+// Last returns a mutator for Last of the Page[*Employee] object.
+// If the field is nil, it will be initialized to a new Employee object.
+func (m *MutatorPagePtrEmployee) Last() EmployeeMutator {
+
+	if m.inner.Last == nil {
+		m.inner.Last = &Employee{}
+	}
+
+	prefix := changes.NewPrefix(MutationPrefixPagePtrEmployeeLast)
+
+	return &MutatorEmployee{
+		inner:   m.inner.Last,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// SetNext sets Next of the Page[*Employee] object
+func (m *MutatorPagePtrEmployee) SetNext(value *Page[*Employee]) bool {
+	return changes.SetPointer(m.changes, "Next", &m.inner.Next, value, m.inner.Next == value)
+}
+
+// Next returns a mutator for Next of the Page[*Employee] object.
+// If the field is nil, it will be initialized to a new Page[*Employee] object.
+func (m *MutatorPagePtrEmployee) Next() PagePtrEmployeeMutator {
+
+	if m.inner.Next == nil {
+		m.inner.Next = &Page[*Employee]{}
+	}
+
+	prefix := changes.NewPrefix(MutationPrefixPagePtrEmployeeNext)
+
+	return &MutatorPagePtrEmployee{
+		inner:   m.inner.Next,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// SetHires sets Hires of the Acme object
+func (m *MutatorAcme) SetHires(value *Page[*Employee]) bool {
+	return changes.SetObject(m.changes, "Hires", &m.inner.Hires, value)
+}
+
+// Hires returns a mutator for Hires of the Acme object.
+func (m *MutatorAcme) Hires() PagePtrEmployeeMutator {
+	prefix := changes.NewPrefix(MutationPrefixAcmeHires)
+
+	return &MutatorPagePtrEmployee{
+		inner:   &m.inner.Hires,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// enumNamesStatus maps the declared Status constants to their names.
+var enumNamesStatus = map[Status]string{
+	StatusActive:    "StatusActive",
+	StatusSuspended: "StatusSuspended",
+	StatusClosed:    "StatusClosed",
+}
+
+// SetStatus mutates the Status of the Acme object,
+// values other than the declared Status constants are rejected.
+func (m *MutatorAcme) SetStatus(value Status) bool {
+	return changes.SetEnum(m.changes, "Status", &m.inner.Status, value, enumNamesStatus)
+}
+
+// SetNumber mutates the Number of the Card object
+func (m *MutatorCard) SetNumber(value string) bool {
+	return changes.Set(m.changes, "Number", &m.inner.Number, value, m.inner.Number == value)
+}
+
+// SetHolder mutates the Holder of the Card object
+func (m *MutatorCard) SetHolder(value string) bool {
+	return changes.Set(m.changes, "Holder", &m.inner.Holder, value, m.inner.Holder == value)
+}
+
+// SetIBAN mutates the IBAN of the Wire object
+func (m *MutatorWire) SetIBAN(value string) bool {
+	return changes.Set(m.changes, "IBAN", &m.inner.IBAN, value, m.inner.IBAN == value)
+}
+
+// SetPayment mutates the Payment of the Acme object
+func (m *MutatorAcme) SetPayment(value PaymentMethod) bool {
+	return changes.Set(m.changes, "Payment", &m.inner.Payment, value, m.inner.Payment == value)
+}
+
+// PaymentAsCard returns a mutator for Payment of the Acme object
+// if it holds a *Card, or nil otherwise.
+func (m *MutatorAcme) PaymentAsCard() CardMutator {
+	object, isImpl := m.inner.Payment.(*Card)
+	if !isImpl || object == nil {
+		return nil
+	}
+
+	prefix := changes.NewPrefixWithKey(MutationPrefixAcmePayment, "Card")
+
+	return &MutatorCard{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// PaymentAsWire returns a mutator for Payment of the Acme object
+// if it holds a *Wire, or nil otherwise.
+func (m *MutatorAcme) PaymentAsWire() WireMutator {
+	object, isImpl := m.inner.Payment.(*Wire)
+	if !isImpl || object == nil {
+		return nil
+	}
+
+	prefix := changes.NewPrefixWithKey(MutationPrefixAcmePayment, "Wire")
+
+	return &MutatorWire{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// SetCapital sets Capital of the Acme object
+func (m *MutatorAcme) SetCapital(value *big.Int) bool {
+	return changes.SetPointer(m.changes, "Capital", &m.inner.Capital, value, (m.inner.Capital == value || m.inner.Capital != nil && value != nil && m.inner.Capital.Cmp(value) == 0))
+}
+
+// SetVisits mutates the Visits of the m2.Metrics object
+func (m *MutatorM2Metrics) SetVisits(value int) bool {
+	return changes.Set(m.changes, "Visits", &m.inner.Visits, value, m.inner.Visits == value)
+}
+
+// SetMetrics sets Metrics of the Acme object
+func (m *MutatorAcme) SetMetrics(value *m2.Metrics) bool {
+	return changes.SetPointer(m.changes, "Metrics", &m.inner.Metrics, value, m.inner.Metrics == value)
+}
+
+// Metrics returns a mutator for Metrics of the Acme object.
+// If the field is nil, it will be initialized to a new m2.Metrics object.
+func (m *MutatorAcme) Metrics() M2MetricsMutator {
+
+	if m.inner.Metrics == nil {
+		m.inner.Metrics = &m2.Metrics{}
+	}
+
+	prefix := changes.NewPrefix(MutationPrefixAcmeMetrics)
+
+	return &MutatorM2Metrics{
+		inner:   m.inner.Metrics,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// SetHeight mutates the Height of the Acme object
+func (m *MutatorAcme) SetHeight(value Meters) bool {
+	return changes.Set(m.changes, "Height", &m.inner.Height, value, m.inner.Height == value)
+}
+
+// SetScores sets Scores of the Acme object
+func (m *MutatorAcme) SetScores(value map[any]int) bool {
+	return changes.SetMap(m.changes, "Scores", &m.inner.Scores, value)
+}
+
+// InsertScores inserts a Scores map element of the Acme object.
+func (m *MutatorAcme) InsertScores(
+	key any,
+	value int,
+) bool {
+	return changes.Insert(m.changes, "Scores", &m.inner.Scores, key, value, func(currentValue, value int) bool {
+		return currentValue == value
+	})
+}
+
+// RemoveScores removes a Scores map element of the Acme object.
+func (m *MutatorAcme) RemoveScores(key any) bool {
+	return changes.RemoveKey(m.changes, "Scores", &m.inner.Scores, key)
+}
+
+// ChangeName mutates the Name of the Supplier object
+func (m *MutatorSupplier) ChangeName(value string) bool {
+	return changes.Set(m.changes, "Supplier name", &m.inner.Name, value, m.inner.Name == value)
+}
+
+// ChangeContact sets Contact of the Supplier object
+func (m *MutatorSupplier) ChangeContact(value *Employee) bool {
+	return changes.SetPointer(m.changes, "Main contact", &m.inner.Contact, value, m.inner.Contact == value)
+}
+
+// Contact returns a mutator for Contact of the Supplier object.
+// If the field is nil, it will be initialized to a new Employee object.
+func (m *MutatorSupplier) Contact() EmployeeMutator {
+
+	if m.inner.Contact == nil {
+		m.inner.Contact = &Employee{}
+	}
+
+	prefix := changes.NewPrefix(MutationPrefixSupplierContact)
+
+	return &MutatorEmployee{
+		inner:   m.inner.Contact,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// ChangeClients sets Clients of the Supplier object
+func (m *MutatorSupplier) ChangeClients(value []*Acme) bool {
+	return changes.SetSlice(m.changes, "Clients", &m.inner.Clients, value)
+}
+
+// AppendClients appends a Clients element of the Supplier object.
+func (m *MutatorSupplier) AppendClients(value ...*Acme) {
+	changes.Append(m.changes, "Clients", &m.inner.Clients, value...)
+}
+
+// RemoveClients removes a Clients element of the Supplier object.
+func (m *MutatorSupplier) RemoveClients(index int) {
+	changes.RemoveIndex(m.changes, "Clients", &m.inner.Clients, index)
+}
+
+// ClientsAt returns a mutator for Clients element at index of the Supplier object.
+func (m *MutatorSupplier) ClientsAt(index int) AcmeMutator {
+	object := m.inner.Clients[index]
+
+	prefix := changes.NewPrefixWithKey(MutationPrefixSupplierClients, changes.IntoKey(object))
+
+	return &MutatorAcme{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// ClientsByPtr returns a mutator for Clients element given by a pointer of type Supplier.
+func (m *MutatorSupplier) ClientsByPtr(ptr *Acme) AcmeMutator {
+	for i, item := range m.inner.Clients {
+		if item == ptr {
+			return m.ClientsAt(i)
+		}
+	}
+	return nil
+}
+
+// SetItems sets Items of the Page[T] object
+func (m *MutatorPage[T]) SetItems(value []T) bool {
+	return changes.SetSlice(m.changes, "Items", &m.inner.Items, value)
+}
+
+// AppendItems appends a Items element of the Page[T] object.
+func (m *MutatorPage[T]) AppendItems(value ...T) {
+	changes.Append(m.changes, "Items", &m.inner.Items, value...)
+}
+
+// RemoveItems removes a Items element of the Page[T] object.
+func (m *MutatorPage[T]) RemoveItems(index int) {
+	changes.RemoveIndex(m.changes, "Items", &m.inner.Items, index)
+}
+
+// SetCursor mutates the Cursor of the Page[T] object
+func (m *MutatorPage[T]) SetCursor(value string) bool {
+	return changes.Set(m.changes, "Cursor", &m.inner.Cursor, value, m.inner.Cursor == value)
+}
+
+// SetLast mutates the Last of the Page[T] object
+func (m *MutatorPage[T]) SetLast(value T) bool {
+	return changes.Set(m.changes, "Last", &m.inner.Last, value, reflect.DeepEqual(m.inner.Last, value))
+}
+
+// SetNext sets Next of the Page[T] object
+func (m *MutatorPage[T]) SetNext(value *Page[T]) bool {
+	return changes.SetPointer(m.changes, "Next", &m.inner.Next, value, m.inner.Next == value)
+}
+
+// Next returns a mutator for Next of the Page[T] object.
+// If the field is nil, it will be initialized to a new Page[T] object.
+func (m *MutatorPage[T]) Next() PageMutator[T] {
+
+	if m.inner.Next == nil {
+		m.inner.Next = &Page[T]{}
+	}
+
+	prefix := changes.NewPrefix(MutationPrefixPageNext)
+
+	return &MutatorPage[T]{
+		inner:   m.inner.Next,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// SetName mutates the Name of the Department object
+func (m *MutatorDepartment) SetName(value string) bool {
+	return changes.Set(m.changes, "Name", &m.inner.Name, value, m.inner.Name == value)
+}
+
+// SetHead sets Head of the Department object
+func (m *MutatorDepartment) SetHead(value *Employee) bool {
+	return changes.SetPointer(m.changes, "Head", &m.inner.Head, value, m.inner.Head == value)
+}
+
+// Head returns a mutator for Head of the Department object.
+// If the field is nil, it will be initialized to a new Employee object.
+func (m *MutatorDepartment) Head() EmployeeMutator {
+
+	if m.inner.Head == nil {
+		m.inner.Head = &Employee{}
+	}
+
+	prefix := changes.NewPrefix(MutationPrefixDepartmentHead)
+
+	return &MutatorEmployee{
+		inner:   m.inner.Head,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// SetSubdepartments sets Subdepartments of the Department object
+func (m *MutatorDepartment) SetSubdepartments(value []*Department) bool {
+	return changes.SetSlice(m.changes, "Subdepartments", &m.inner.Subdepartments, value)
+}
+
+// AppendSubdepartments appends a Subdepartments element of the Department object.
+func (m *MutatorDepartment) AppendSubdepartments(value ...*Department) {
+	changes.Append(m.changes, "Subdepartments", &m.inner.Subdepartments, value...)
+}
+
+// RemoveSubdepartments removes a Subdepartments element of the Department object.
+func (m *MutatorDepartment) RemoveSubdepartments(index int) {
+	changes.RemoveIndex(m.changes, "Subdepartments", &m.inner.Subdepartments, index)
+}
+
+// SubdepartmentsAt returns a mutator for Subdepartments element at index of the Department object.
+func (m *MutatorDepartment) SubdepartmentsAt(index int) DepartmentMutator {
+	object := m.inner.Subdepartments[index]
+
+	prefix := changes.NewPrefixWithKey(MutationPrefixDepartmentSubdepartments, changes.IntoKey(object))
+
+	return &MutatorDepartment{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// SubdepartmentsByPtr returns a mutator for Subdepartments element given by a pointer of type Department.
+func (m *MutatorDepartment) SubdepartmentsByPtr(ptr *Department) DepartmentMutator {
+	for i, item := range m.inner.Subdepartments {
+		if item == ptr {
+			return m.SubdepartmentsAt(i)
+		}
+	}
+	return nil
+}
+
+// SetName mutates the Name of the Team object
+func (m *MutatorTeam) SetName(value string) bool {
+	return changes.Set(m.changes, "Name", &m.inner.Name, value, m.inner.Name == value)
+}
+
+// SetMembers sets Members of the Team object
+func (m *MutatorTeam) SetMembers(value []*Employee) bool {
+	return changes.SetSlice(m.changes, "Members", &m.inner.Members, value)
+}
+
+// AppendMembers appends a Members element of the Team object.
+func (m *MutatorTeam) AppendMembers(value ...*Employee) {
+	changes.Append(m.changes, "Members", &m.inner.Members, value...)
+}
+
+// RemoveMembers removes a Members element of the Team object.
+func (m *MutatorTeam) RemoveMembers(index int) {
+	changes.RemoveIndex(m.changes, "Members", &m.inner.Members, index)
+}
+
+// MembersAt returns a mutator for Members element at index of the Team object.
+func (m *MutatorTeam) MembersAt(index int) EmployeeMutator {
+	object := m.inner.Members[index]
+
+	prefix := changes.NewPrefixWithKey(MutationPrefixTeamMembers, changes.IntoKey(object))
+
+	return &MutatorEmployee{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// MembersByPtr returns a mutator for Members element given by a pointer of type Team.
+func (m *MutatorTeam) MembersByPtr(ptr *Employee) EmployeeMutator {
+	for i, item := range m.inner.Members {
+		if item == ptr {
+			return m.MembersAt(i)
+		}
+	}
+	return nil
+}
+
+// SetSpinoff sets Spinoff of the Team object
+func (m *MutatorTeam) SetSpinoff(value *Department) bool {
+	return changes.SetPointer(m.changes, "Spinoff", &m.inner.Spinoff, value, m.inner.Spinoff == value)
+}
+
+// Spinoff returns a mutator for Spinoff of the Team object.
+// If the field is nil, it will be initialized to a new Department object.
+func (m *MutatorTeam) Spinoff() DepartmentMutator {
+
+	if m.inner.Spinoff == nil {
+		m.inner.Spinoff = &Department{}
+	}
+
+	prefix := changes.NewPrefix(MutationPrefixTeamSpinoff)
+
+	return &MutatorDepartment{
+		inner:   m.inner.Spinoff,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// SetTeams sets Teams of the Department object
+func (m *MutatorDepartment) SetTeams(value map[string]*Team) bool {
+	return changes.SetMap(m.changes, "Teams", &m.inner.Teams, value)
+}
+
+// InsertTeams inserts a Teams map element of the Department object.
+func (m *MutatorDepartment) InsertTeams(
+	key string,
+	value *Team,
+) bool {
+	return changes.Insert(m.changes, "Teams", &m.inner.Teams, key, value, func(currentValue, value *Team) bool {
+		return currentValue == value
+	})
+}
+
+// RemoveTeams removes a Teams map element of the Department object.
+func (m *MutatorDepartment) RemoveTeams(key string) bool {
+	return changes.RemoveKey(m.changes, "Teams", &m.inner.Teams, key)
+}
+
+// TeamsWithKey returns a mutator for Teams map element Department object with given key.
+func (m *MutatorDepartment) TeamsWithKey(key string) TeamMutator {
+	object := m.inner.Teams[key]
+
+	prefix := changes.NewPrefixWithKey(MutationPrefixDepartmentTeams, changes.IntoKey(object))
+
+	return &MutatorTeam{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// SetSKU mutates the SKU of the Item object
+func (m *MutatorItem) SetSKU(value string) bool {
+	return changes.Set(m.changes, "SKU", &m.inner.SKU, value, m.inner.SKU == value)
+}
+
+// SetQuantity mutates the Quantity of the Item object
+func (m *MutatorItem) SetQuantity(value int) bool {
+	return changes.Set(m.changes, "Quantity", &m.inner.Quantity, value, m.inner.Quantity == value)
+}
+
+// SetLineItems sets LineItems of the Order object
+func (m *MutatorOrder) SetLineItems(value []*Item) bool {
+	return changes.SetSlice(m.changes, "LineItems", &m.inner.LineItems, value)
+}
+
+// AppendLineItems appends a LineItems element of the Order object.
+func (m *MutatorOrder) AppendLineItems(value ...*Item) {
+	changes.Append(m.changes, "LineItems", &m.inner.LineItems, value...)
+}
+
+// RemoveLineItems removes a LineItems element of the Order object.
+func (m *MutatorOrder) RemoveLineItems(index int) {
+	changes.RemoveIndex(m.changes, "LineItems", &m.inner.LineItems, index)
+}
+
+// LineItemsAt returns a mutator for LineItems element at index of the Order object.
+func (m *MutatorOrder) LineItemsAt(index int) ItemMutator {
+	object := m.inner.LineItems[index]
+
+	prefix := changes.NewPrefixWithKey(MutationPrefixOrder_LineItems, changes.IntoKey(object))
+
+	return &MutatorItem{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// LineItemsByPtr returns a mutator for LineItems element given by a pointer of type Order.
+func (m *MutatorOrder) LineItemsByPtr(ptr *Item) ItemMutator {
+	for i, item := range m.inner.LineItems {
+		if item == ptr {
+			return m.LineItemsAt(i)
+		}
+	}
+	return nil
+}
+
+// SetItems sets Items of the OrderLine object
+func (m *MutatorOrderLine) SetItems(value []*Item) bool {
+	return changes.SetSlice(m.changes, "Items", &m.inner.Items, value)
+}
+
+// AppendItems appends a Items element of the OrderLine object.
+func (m *MutatorOrderLine) AppendItems(value ...*Item) {
+	changes.Append(m.changes, "Items", &m.inner.Items, value...)
+}
+
+// RemoveItems removes a Items element of the OrderLine object.
+func (m *MutatorOrderLine) RemoveItems(index int) {
+	changes.RemoveIndex(m.changes, "Items", &m.inner.Items, index)
+}
+
+// ItemsAt returns a mutator for Items element at index of the OrderLine object.
+func (m *MutatorOrderLine) ItemsAt(index int) ItemMutator {
+	object := m.inner.Items[index]
+
+	prefix := changes.NewPrefixWithKey(MutationPrefixOrderLine_Items, changes.IntoKey(object))
+
+	return &MutatorItem{
+		inner:   object,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// ItemsByPtr returns a mutator for Items element given by a pointer of type OrderLine.
+func (m *MutatorOrderLine) ItemsByPtr(ptr *Item) ItemMutator {
+	for i, item := range m.inner.Items {
+		if item == ptr {
+			return m.ItemsAt(i)
+		}
+	}
+	return nil
+}
+
+// SetLine sets Line of the Order object
+func (m *MutatorOrder) SetLine(value *OrderLine) bool {
+	return changes.SetPointer(m.changes, "Line", &m.inner.Line, value, m.inner.Line == value)
+}
+
+// Line returns a mutator for Line of the Order object.
+// If the field is nil, it will be initialized to a new OrderLine object.
+func (m *MutatorOrder) Line() OrderLineMutator {
+
+	if m.inner.Line == nil {
+		m.inner.Line = &OrderLine{}
+	}
+
+	prefix := changes.NewPrefix(MutationPrefixOrderLine)
+
+	return &MutatorOrderLine{
+		inner:   m.inner.Line,
+		changes: changes.NewChainedLogger(prefix, m.changes),
+	}
+}
+
+// AcmeMutator is implemented by MutatorAcme, and by FakeAcmeMutator in tests.
+type AcmeMutator interface {
+	FormatChanges() []string
+	SetAudit(value *Audit) bool
+	Audit() AuditMutator
+	SetCreatedBy(value string) bool
+	SetRevision(value int) bool
+	SetID(value string) bool
+	SetName(value string) bool
+	SetYearOfBirth(value int) bool
+	SetEmployees(value []*Employee) bool
+	AppendEmployees(value ...*Employee)
+	RemoveEmployees(index int)
+	EmployeesAt(index int) EmployeeMutator
+	EmployeesByPtr(ptr *Employee) EmployeeMutator
+	SetAddress(value *Address) bool
+	Address() AddressMutator
+	Vat() VatMutator
+	SetNicknames(value map[string]*Employee) bool
+	InsertNicknames(key string, value *Employee) bool
+	RemoveNicknames(key string) bool
+	NicknamesWithKey(key string) EmployeeMutator
+	SetEquity(value map[*Employee]int) bool
+	InsertEquity(key *Employee, value int) bool
+	RemoveEquity(key *Employee) bool
+	SetBilling(value *billing.Account) bool
+	Billing() BillingAccountMutator
+	SetTags(value map[string][]Tag) bool
+	InsertTags(key string, value []Tag) bool
+	RemoveTags(key string) bool
+	TagsWithKey(key string) SliceTagMutator
+	SetShifts(value [][]string) bool
+	AppendShifts(value ...[]string)
+	RemoveShifts(index int)
+	ShiftsAt(index int) SliceStringMutator
+	SetRegions(value map[string]map[int]string) bool
+	InsertRegions(key string, value map[int]string) bool
+	RemoveRegions(key string) bool
+	RegionsWithKey(key string) MapIntStringMutator
+	SetHires(value *Page[*Employee]) bool
+	Hires() PagePtrEmployeeMutator
+	SetStatus(value Status) bool
+	SetPayment(value PaymentMethod) bool
+	PaymentAsCard() CardMutator
+	PaymentAsWire() WireMutator
+	SetCapital(value *big.Int) bool
+	SetMetrics(value *m2.Metrics) bool
+	Metrics() M2MetricsMutator
+	SetHeight(value Meters) bool
+	SetScores(value map[any]int) bool
+	InsertScores(key any, value int) bool
+	RemoveScores(key any) bool
+}
+
+var _ AcmeMutator = (*MutatorAcme)(nil)
+
+// FakeAcmeMutator implements AcmeMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeAcmeMutator struct {
+	recorder *changes.Recorder
+	path     string
+}
+
+// NewFakeAcmeMutator creates a FakeAcmeMutator recording calls into recorder.
+func NewFakeAcmeMutator(recorder *changes.Recorder) *FakeAcmeMutator {
+	return &FakeAcmeMutator{
+		recorder: recorder,
+	}
+}
+
+// FormatChanges returns the recorded calls.
+func (m *FakeAcmeMutator) FormatChanges() []string {
+	return m.recorder.ToString()
+}
+
+// SetAudit records the call, reporting a change.
+func (m *FakeAcmeMutator) SetAudit(value *Audit) bool {
+	m.recorder.Record(m.path+"SetAudit", value)
+	return true
+}
+
+// Audit returns a fake recording the calls to the methods of the mutator.
+func (m *FakeAcmeMutator) Audit() AuditMutator {
+	return &FakeAuditMutator{
+		recorder: m.recorder,
+		path:     m.path + "Audit().",
+	}
+}
+
+// SetCreatedBy records the call, reporting a change.
+func (m *FakeAcmeMutator) SetCreatedBy(value string) bool {
+	m.recorder.Record(m.path+"SetCreatedBy", value)
+	return true
+}
+
+// SetRevision records the call, reporting a change.
+func (m *FakeAcmeMutator) SetRevision(value int) bool {
+	m.recorder.Record(m.path+"SetRevision", value)
+	return true
+}
+
+// SetID records the call, reporting a change.
+func (m *FakeAcmeMutator) SetID(value string) bool {
+	m.recorder.Record(m.path+"SetID", value)
+	return true
+}
+
+// SetName records the call, reporting a change.
+func (m *FakeAcmeMutator) SetName(value string) bool {
+	m.recorder.Record(m.path+"SetName", value)
+	return true
+}
+
+// SetYearOfBirth records the call, reporting a change.
+func (m *FakeAcmeMutator) SetYearOfBirth(value int) bool {
+	m.recorder.Record(m.path+"SetYearOfBirth", value)
+	return true
+}
+
+// SetEmployees records the call, reporting a change.
+func (m *FakeAcmeMutator) SetEmployees(value []*Employee) bool {
+	m.recorder.Record(m.path+"SetEmployees", value)
+	return true
+}
+
+// AppendEmployees records the call.
+func (m *FakeAcmeMutator) AppendEmployees(value ...*Employee) {
+	m.recorder.Record(m.path+"AppendEmployees", value)
+}
+
+// RemoveEmployees records the call.
+func (m *FakeAcmeMutator) RemoveEmployees(index int) {
+	m.recorder.Record(m.path+"RemoveEmployees", index)
+}
+
+// EmployeesAt returns a fake recording the calls to the methods of the mutator.
+func (m *FakeAcmeMutator) EmployeesAt(index int) EmployeeMutator {
+	return &FakeEmployeeMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("EmployeesAt(%+v).", index),
+	}
+}
+
+// EmployeesByPtr returns a fake recording the calls to the methods of the mutator.
+func (m *FakeAcmeMutator) EmployeesByPtr(ptr *Employee) EmployeeMutator {
+	return &FakeEmployeeMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("EmployeesByPtr(%+v).", ptr),
+	}
+}
+
+// SetAddress records the call, reporting a change.
+func (m *FakeAcmeMutator) SetAddress(value *Address) bool {
+	m.recorder.Record(m.path+"SetAddress", value)
+	return true
+}
+
+// Address returns a fake recording the calls to the methods of the mutator.
+func (m *FakeAcmeMutator) Address() AddressMutator {
+	return &FakeAddressMutator{
+		recorder: m.recorder,
+		path:     m.path + "Address().",
+	}
+}
+
+// Vat returns a fake recording the calls to the methods of the mutator.
+func (m *FakeAcmeMutator) Vat() VatMutator {
+	return &FakeVatMutator{
+		recorder: m.recorder,
+		path:     m.path + "Vat().",
+	}
+}
+
+// SetNicknames records the call, reporting a change.
+func (m *FakeAcmeMutator) SetNicknames(value map[string]*Employee) bool {
+	m.recorder.Record(m.path+"SetNicknames", value)
+	return true
+}
+
+// InsertNicknames records the call, reporting a change.
+func (m *FakeAcmeMutator) InsertNicknames(key string, value *Employee) bool {
+	m.recorder.Record(m.path+"InsertNicknames", key, value)
+	return true
+}
+
+// RemoveNicknames records the call, reporting a change.
+func (m *FakeAcmeMutator) RemoveNicknames(key string) bool {
+	m.recorder.Record(m.path+"RemoveNicknames", key)
+	return true
+}
+
+// NicknamesWithKey returns a fake recording the calls to the methods of the mutator.
+func (m *FakeAcmeMutator) NicknamesWithKey(key string) EmployeeMutator {
+	return &FakeEmployeeMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("NicknamesWithKey(%+v).", key),
+	}
+}
+
+// SetEquity records the call, reporting a change.
+func (m *FakeAcmeMutator) SetEquity(value map[*Employee]int) bool {
+	m.recorder.Record(m.path+"SetEquity", value)
+	return true
+}
+
+// InsertEquity records the call, reporting a change.
+func (m *FakeAcmeMutator) InsertEquity(key *Employee, value int) bool {
+	m.recorder.Record(m.path+"InsertEquity", key, value)
+	return true
+}
+
+// RemoveEquity records the call, reporting a change.
+func (m *FakeAcmeMutator) RemoveEquity(key *Employee) bool {
+	m.recorder.Record(m.path+"RemoveEquity", key)
+	return true
+}
+
+// SetBilling records the call, reporting a change.
+func (m *FakeAcmeMutator) SetBilling(value *billing.Account) bool {
+	m.recorder.Record(m.path+"SetBilling", value)
+	return true
+}
+
+// Billing returns a fake recording the calls to the methods of the mutator.
+func (m *FakeAcmeMutator) Billing() BillingAccountMutator {
+	return &FakeBillingAccountMutator{
+		recorder: m.recorder,
+		path:     m.path + "Billing().",
+	}
+}
+
+// SetTags records the call, reporting a change.
+func (m *FakeAcmeMutator) SetTags(value map[string][]Tag) bool {
+	m.recorder.Record(m.path+"SetTags", value)
+	return true
+}
+
+// InsertTags records the call, reporting a change.
+func (m *FakeAcmeMutator) InsertTags(key string, value []Tag) bool {
+	m.recorder.Record(m.path+"InsertTags", key, value)
+	return true
+}
+
+// RemoveTags records the call, reporting a change.
+func (m *FakeAcmeMutator) RemoveTags(key string) bool {
+	m.recorder.Record(m.path+"RemoveTags", key)
+	return true
+}
+
+// TagsWithKey returns a fake recording the calls to the methods of the mutator.
+func (m *FakeAcmeMutator) TagsWithKey(key string) SliceTagMutator {
+	return &FakeSliceTagMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("TagsWithKey(%+v).", key),
+	}
+}
+
+// SetShifts records the call, reporting a change.
+func (m *FakeAcmeMutator) SetShifts(value [][]string) bool {
+	m.recorder.Record(m.path+"SetShifts", value)
+	return true
+}
+
+// AppendShifts records the call.
+func (m *FakeAcmeMutator) AppendShifts(value ...[]string) {
+	m.recorder.Record(m.path+"AppendShifts", value)
+}
+
+// RemoveShifts records the call.
+func (m *FakeAcmeMutator) RemoveShifts(index int) {
+	m.recorder.Record(m.path+"RemoveShifts", index)
+}
+
+// ShiftsAt returns a fake recording the calls to the methods of the mutator.
+func (m *FakeAcmeMutator) ShiftsAt(index int) SliceStringMutator {
+	return &FakeSliceStringMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("ShiftsAt(%+v).", index),
+	}
+}
+
+// SetRegions records the call, reporting a change.
+func (m *FakeAcmeMutator) SetRegions(value map[string]map[int]string) bool {
+	m.recorder.Record(m.path+"SetRegions", value)
+	return true
+}
+
+// InsertRegions records the call, reporting a change.
+func (m *FakeAcmeMutator) InsertRegions(key string, value map[int]string) bool {
+	m.recorder.Record(m.path+"InsertRegions", key, value)
+	return true
+}
+
+// RemoveRegions records the call, reporting a change.
+func (m *FakeAcmeMutator) RemoveRegions(key string) bool {
+	m.recorder.Record(m.path+"RemoveRegions", key)
+	return true
+}
+
+// RegionsWithKey returns a fake recording the calls to the methods of the mutator.
+func (m *FakeAcmeMutator) RegionsWithKey(key string) MapIntStringMutator {
+	return &FakeMapIntStringMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("RegionsWithKey(%+v).", key),
+	}
+}
+
+// SetHires records the call, reporting a change.
+func (m *FakeAcmeMutator) SetHires(value *Page[*Employee]) bool {
+	m.recorder.Record(m.path+"SetHires", value)
+	return true
+}
+
+// Hires returns a fake recording the calls to the methods of the mutator.
+func (m *FakeAcmeMutator) Hires() PagePtrEmployeeMutator {
+	return &FakePagePtrEmployeeMutator{
+		recorder: m.recorder,
+		path:     m.path + "Hires().",
+	}
+}
+
+// SetStatus records the call, reporting a change.
+func (m *FakeAcmeMutator) SetStatus(value Status) bool {
+	m.recorder.Record(m.path+"SetStatus", value)
+	return true
+}
+
+// SetPayment records the call, reporting a change.
+func (m *FakeAcmeMutator) SetPayment(value PaymentMethod) bool {
+	m.recorder.Record(m.path+"SetPayment", value)
+	return true
+}
+
+// PaymentAsCard returns a fake recording the calls to the methods of the mutator.
+func (m *FakeAcmeMutator) PaymentAsCard() CardMutator {
+	return &FakeCardMutator{
+		recorder: m.recorder,
+		path:     m.path + "PaymentAsCard().",
+	}
+}
+
+// PaymentAsWire returns a fake recording the calls to the methods of the mutator.
+func (m *FakeAcmeMutator) PaymentAsWire() WireMutator {
+	return &FakeWireMutator{
+		recorder: m.recorder,
+		path:     m.path + "PaymentAsWire().",
+	}
+}
+
+// SetCapital records the call, reporting a change.
+func (m *FakeAcmeMutator) SetCapital(value *big.Int) bool {
+	m.recorder.Record(m.path+"SetCapital", value)
+	return true
+}
+
+// SetMetrics records the call, reporting a change.
+func (m *FakeAcmeMutator) SetMetrics(value *m2.Metrics) bool {
+	m.recorder.Record(m.path+"SetMetrics", value)
+	return true
+}
+
+// Metrics returns a fake recording the calls to the methods of the mutator.
+func (m *FakeAcmeMutator) Metrics() M2MetricsMutator {
+	return &FakeM2MetricsMutator{
+		recorder: m.recorder,
+		path:     m.path + "Metrics().",
+	}
+}
+
+// SetHeight records the call, reporting a change.
+func (m *FakeAcmeMutator) SetHeight(value Meters) bool {
+	m.recorder.Record(m.path+"SetHeight", value)
+	return true
+}
+
+// SetScores records the call, reporting a change.
+func (m *FakeAcmeMutator) SetScores(value map[any]int) bool {
+	m.recorder.Record(m.path+"SetScores", value)
+	return true
+}
+
+// InsertScores records the call, reporting a change.
+func (m *FakeAcmeMutator) InsertScores(key any, value int) bool {
+	m.recorder.Record(m.path+"InsertScores", key, value)
+	return true
+}
+
+// RemoveScores records the call, reporting a change.
+func (m *FakeAcmeMutator) RemoveScores(key any) bool {
+	m.recorder.Record(m.path+"RemoveScores", key)
+	return true
+}
+
+// SupplierMutator is implemented by MutatorSupplier, and by FakeSupplierMutator in tests.
+type SupplierMutator interface {
+	FormatChanges() []string
+	ChangeName(value string) bool
+	ChangeContact(value *Employee) bool
+	Contact() EmployeeMutator
+	ChangeClients(value []*Acme) bool
+	AppendClients(value ...*Acme)
+	RemoveClients(index int)
+	ClientsAt(index int) AcmeMutator
+	ClientsByPtr(ptr *Acme) AcmeMutator
+}
+
+var _ SupplierMutator = (*MutatorSupplier)(nil)
+
+// FakeSupplierMutator implements SupplierMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeSupplierMutator struct {
+	recorder *changes.Recorder
+	path     string
+}
+
+// NewFakeSupplierMutator creates a FakeSupplierMutator recording calls into recorder.
+func NewFakeSupplierMutator(recorder *changes.Recorder) *FakeSupplierMutator {
+	return &FakeSupplierMutator{
+		recorder: recorder,
+	}
+}
+
+// FormatChanges returns the recorded calls.
+func (m *FakeSupplierMutator) FormatChanges() []string {
+	return m.recorder.ToString()
+}
+
+// ChangeName records the call, reporting a change.
+func (m *FakeSupplierMutator) ChangeName(value string) bool {
+	m.recorder.Record(m.path+"ChangeName", value)
+	return true
+}
+
+// ChangeContact records the call, reporting a change.
+func (m *FakeSupplierMutator) ChangeContact(value *Employee) bool {
+	m.recorder.Record(m.path+"ChangeContact", value)
+	return true
+}
+
+// Contact returns a fake recording the calls to the methods of the mutator.
+func (m *FakeSupplierMutator) Contact() EmployeeMutator {
+	return &FakeEmployeeMutator{
+		recorder: m.recorder,
+		path:     m.path + "Contact().",
+	}
+}
+
+// ChangeClients records the call, reporting a change.
+func (m *FakeSupplierMutator) ChangeClients(value []*Acme) bool {
+	m.recorder.Record(m.path+"ChangeClients", value)
+	return true
+}
+
+// AppendClients records the call.
+func (m *FakeSupplierMutator) AppendClients(value ...*Acme) {
+	m.recorder.Record(m.path+"AppendClients", value)
+}
+
+// RemoveClients records the call.
+func (m *FakeSupplierMutator) RemoveClients(index int) {
+	m.recorder.Record(m.path+"RemoveClients", index)
+}
+
+// ClientsAt returns a fake recording the calls to the methods of the mutator.
+func (m *FakeSupplierMutator) ClientsAt(index int) AcmeMutator {
+	return &FakeAcmeMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("ClientsAt(%+v).", index),
+	}
+}
+
+// ClientsByPtr returns a fake recording the calls to the methods of the mutator.
+func (m *FakeSupplierMutator) ClientsByPtr(ptr *Acme) AcmeMutator {
+	return &FakeAcmeMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("ClientsByPtr(%+v).", ptr),
+	}
+}
+
+// PageMutator is implemented by MutatorPage, and by FakePageMutator in tests.
+type PageMutator[T any] interface {
+	FormatChanges() []string
+	SetItems(value []T) bool
+	AppendItems(value ...T)
+	RemoveItems(index int)
+	SetCursor(value string) bool
+	SetLast(value T) bool
+	SetNext(value *Page[T]) bool
+	Next() PageMutator[T]
+}
+
+// FakePageMutator implements PageMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakePageMutator[T any] struct {
+	recorder *changes.Recorder
+	path     string
+}
+
+// NewFakePageMutator creates a FakePageMutator recording calls into recorder.
+func NewFakePageMutator[T any](recorder *changes.Recorder) *FakePageMutator[T] {
+	return &FakePageMutator[T]{
+		recorder: recorder,
+	}
+}
+
+// FormatChanges returns the recorded calls.
+func (m *FakePageMutator[T]) FormatChanges() []string {
+	return m.recorder.ToString()
+}
+
+// SetItems records the call, reporting a change.
+func (m *FakePageMutator[T]) SetItems(value []T) bool {
+	m.recorder.Record(m.path+"SetItems", value)
+	return true
+}
+
+// AppendItems records the call.
+func (m *FakePageMutator[T]) AppendItems(value ...T) {
+	m.recorder.Record(m.path+"AppendItems", value)
+}
+
+// RemoveItems records the call.
+func (m *FakePageMutator[T]) RemoveItems(index int) {
+	m.recorder.Record(m.path+"RemoveItems", index)
+}
+
+// SetCursor records the call, reporting a change.
+func (m *FakePageMutator[T]) SetCursor(value string) bool {
+	m.recorder.Record(m.path+"SetCursor", value)
+	return true
+}
+
+// SetLast records the call, reporting a change.
+func (m *FakePageMutator[T]) SetLast(value T) bool {
+	m.recorder.Record(m.path+"SetLast", value)
+	return true
+}
+
+// SetNext records the call, reporting a change.
+func (m *FakePageMutator[T]) SetNext(value *Page[T]) bool {
+	m.recorder.Record(m.path+"SetNext", value)
+	return true
+}
+
+// Next returns a fake recording the calls to the methods of the mutator.
+func (m *FakePageMutator[T]) Next() PageMutator[T] {
+	return &FakePageMutator[T]{
+		recorder: m.recorder,
+		path:     m.path + "Next().",
+	}
+}
+
+// DepartmentMutator is implemented by MutatorDepartment, and by FakeDepartmentMutator in tests.
+type DepartmentMutator interface {
+	FormatChanges() []string
+	SetName(value string) bool
+	SetHead(value *Employee) bool
+	Head() EmployeeMutator
+	SetSubdepartments(value []*Department) bool
+	AppendSubdepartments(value ...*Department)
+	RemoveSubdepartments(index int)
+	SubdepartmentsAt(index int) DepartmentMutator
+	SubdepartmentsByPtr(ptr *Department) DepartmentMutator
+	SetTeams(value map[string]*Team) bool
+	InsertTeams(key string, value *Team) bool
+	RemoveTeams(key string) bool
+	TeamsWithKey(key string) TeamMutator
+}
+
+var _ DepartmentMutator = (*MutatorDepartment)(nil)
+
+// FakeDepartmentMutator implements DepartmentMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeDepartmentMutator struct {
+	recorder *changes.Recorder
+	path     string
+}
+
+// NewFakeDepartmentMutator creates a FakeDepartmentMutator recording calls into recorder.
+func NewFakeDepartmentMutator(recorder *changes.Recorder) *FakeDepartmentMutator {
+	return &FakeDepartmentMutator{
+		recorder: recorder,
+	}
+}
+
+// FormatChanges returns the recorded calls.
+func (m *FakeDepartmentMutator) FormatChanges() []string {
+	return m.recorder.ToString()
+}
+
+// SetName records the call, reporting a change.
+func (m *FakeDepartmentMutator) SetName(value string) bool {
+	m.recorder.Record(m.path+"SetName", value)
+	return true
+}
+
+// SetHead records the call, reporting a change.
+func (m *FakeDepartmentMutator) SetHead(value *Employee) bool {
+	m.recorder.Record(m.path+"SetHead", value)
+	return true
+}
+
+// Head returns a fake recording the calls to the methods of the mutator.
+func (m *FakeDepartmentMutator) Head() EmployeeMutator {
+	return &FakeEmployeeMutator{
+		recorder: m.recorder,
+		path:     m.path + "Head().",
+	}
+}
+
+// SetSubdepartments records the call, reporting a change.
+func (m *FakeDepartmentMutator) SetSubdepartments(value []*Department) bool {
+	m.recorder.Record(m.path+"SetSubdepartments", value)
+	return true
+}
+
+// AppendSubdepartments records the call.
+func (m *FakeDepartmentMutator) AppendSubdepartments(value ...*Department) {
+	m.recorder.Record(m.path+"AppendSubdepartments", value)
+}
+
+// RemoveSubdepartments records the call.
+func (m *FakeDepartmentMutator) RemoveSubdepartments(index int) {
+	m.recorder.Record(m.path+"RemoveSubdepartments", index)
+}
+
+// SubdepartmentsAt returns a fake recording the calls to the methods of the mutator.
+func (m *FakeDepartmentMutator) SubdepartmentsAt(index int) DepartmentMutator {
+	return &FakeDepartmentMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("SubdepartmentsAt(%+v).", index),
+	}
+}
+
+// SubdepartmentsByPtr returns a fake recording the calls to the methods of the mutator.
+func (m *FakeDepartmentMutator) SubdepartmentsByPtr(ptr *Department) DepartmentMutator {
+	return &FakeDepartmentMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("SubdepartmentsByPtr(%+v).", ptr),
+	}
+}
+
+// SetTeams records the call, reporting a change.
+func (m *FakeDepartmentMutator) SetTeams(value map[string]*Team) bool {
+	m.recorder.Record(m.path+"SetTeams", value)
+	return true
+}
+
+// InsertTeams records the call, reporting a change.
+func (m *FakeDepartmentMutator) InsertTeams(key string, value *Team) bool {
+	m.recorder.Record(m.path+"InsertTeams", key, value)
+	return true
+}
+
+// RemoveTeams records the call, reporting a change.
+func (m *FakeDepartmentMutator) RemoveTeams(key string) bool {
+	m.recorder.Record(m.path+"RemoveTeams", key)
+	return true
+}
+
+// TeamsWithKey returns a fake recording the calls to the methods of the mutator.
+func (m *FakeDepartmentMutator) TeamsWithKey(key string) TeamMutator {
+	return &FakeTeamMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("TeamsWithKey(%+v).", key),
+	}
+}
+
+// OrderMutator is implemented by MutatorOrder, and by FakeOrderMutator in tests.
+type OrderMutator interface {
+	FormatChanges() []string
+	SetLineItems(value []*Item) bool
+	AppendLineItems(value ...*Item)
+	RemoveLineItems(index int)
+	LineItemsAt(index int) ItemMutator
+	LineItemsByPtr(ptr *Item) ItemMutator
+	SetLine(value *OrderLine) bool
+	Line() OrderLineMutator
+}
+
+var _ OrderMutator = (*MutatorOrder)(nil)
+
+// FakeOrderMutator implements OrderMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeOrderMutator struct {
+	recorder *changes.Recorder
+	path     string
+}
+
+// NewFakeOrderMutator creates a FakeOrderMutator recording calls into recorder.
+func NewFakeOrderMutator(recorder *changes.Recorder) *FakeOrderMutator {
+	return &FakeOrderMutator{
+		recorder: recorder,
+	}
+}
+
+// FormatChanges returns the recorded calls.
+func (m *FakeOrderMutator) FormatChanges() []string {
+	return m.recorder.ToString()
+}
+
+// SetLineItems records the call, reporting a change.
+func (m *FakeOrderMutator) SetLineItems(value []*Item) bool {
+	m.recorder.Record(m.path+"SetLineItems", value)
+	return true
+}
+
+// AppendLineItems records the call.
+func (m *FakeOrderMutator) AppendLineItems(value ...*Item) {
+	m.recorder.Record(m.path+"AppendLineItems", value)
+}
+
+// RemoveLineItems records the call.
+func (m *FakeOrderMutator) RemoveLineItems(index int) {
+	m.recorder.Record(m.path+"RemoveLineItems", index)
+}
+
+// LineItemsAt returns a fake recording the calls to the methods of the mutator.
+func (m *FakeOrderMutator) LineItemsAt(index int) ItemMutator {
+	return &FakeItemMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("LineItemsAt(%+v).", index),
+	}
+}
+
+// LineItemsByPtr returns a fake recording the calls to the methods of the mutator.
+func (m *FakeOrderMutator) LineItemsByPtr(ptr *Item) ItemMutator {
+	return &FakeItemMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("LineItemsByPtr(%+v).", ptr),
+	}
+}
+
+// SetLine records the call, reporting a change.
+func (m *FakeOrderMutator) SetLine(value *OrderLine) bool {
+	m.recorder.Record(m.path+"SetLine", value)
+	return true
+}
+
+// Line returns a fake recording the calls to the methods of the mutator.
+func (m *FakeOrderMutator) Line() OrderLineMutator {
+	return &FakeOrderLineMutator{
+		recorder: m.recorder,
+		path:     m.path + "Line().",
+	}
+}
+
+// AuditMutator is implemented by MutatorAudit, and by FakeAuditMutator in tests.
+type AuditMutator interface {
+	SetName(value string) bool
+	SetCreatedBy(value string) bool
+	SetRevision(value int) bool
+}
+
+var _ AuditMutator = (*MutatorAudit)(nil)
+
+// FakeAuditMutator implements AuditMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeAuditMutator struct {
+	recorder *changes.Recorder
+	path     string
+}
+
+// NewFakeAuditMutator creates a FakeAuditMutator recording calls into recorder.
+func NewFakeAuditMutator(recorder *changes.Recorder) *FakeAuditMutator {
+	return &FakeAuditMutator{
+		recorder: recorder,
+	}
+}
+
+// SetName records the call, reporting a change.
+func (m *FakeAuditMutator) SetName(value string) bool {
+	m.recorder.Record(m.path+"SetName", value)
+	return true
+}
+
+// SetCreatedBy records the call, reporting a change.
+func (m *FakeAuditMutator) SetCreatedBy(value string) bool {
+	m.recorder.Record(m.path+"SetCreatedBy", value)
+	return true
+}
+
+// SetRevision records the call, reporting a change.
+func (m *FakeAuditMutator) SetRevision(value int) bool {
+	m.recorder.Record(m.path+"SetRevision", value)
+	return true
+}
+
+// EmployeeMutator is implemented by MutatorEmployee, and by FakeEmployeeMutator in tests.
+type EmployeeMutator interface {
+	SetName(value string) bool
+	SetPosition(value string) bool
+	SetWage(value int) bool
+	SetJoinedAt(value time.Time) bool
+	SetProjects(value []Project) bool
+	AppendProjects(value ...Project)
+	RemoveProjects(index int)
+	ProjectsAt(index int) ProjectMutator
+	SetAudit(value *Audit) bool
+	Audit() AuditMutator
+	SetCreatedBy(value string) bool
+	SetRevision(value int) bool
+}
+
+var _ EmployeeMutator = (*MutatorEmployee)(nil)
+
+// FakeEmployeeMutator implements EmployeeMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeEmployeeMutator struct {
+	recorder *changes.Recorder
+	path     string
+}
+
+// NewFakeEmployeeMutator creates a FakeEmployeeMutator recording calls into recorder.
+func NewFakeEmployeeMutator(recorder *changes.Recorder) *FakeEmployeeMutator {
+	return &FakeEmployeeMutator{
+		recorder: recorder,
+	}
+}
+
+// SetName records the call, reporting a change.
+func (m *FakeEmployeeMutator) SetName(value string) bool {
+	m.recorder.Record(m.path+"SetName", value)
+	return true
+}
+
+// SetPosition records the call, reporting a change.
+func (m *FakeEmployeeMutator) SetPosition(value string) bool {
+	m.recorder.Record(m.path+"SetPosition", value)
+	return true
+}
+
+// SetWage records the call, reporting a change.
+func (m *FakeEmployeeMutator) SetWage(value int) bool {
+	m.recorder.Record(m.path+"SetWage", value)
+	return true
+}
+
+// SetJoinedAt records the call, reporting a change.
+func (m *FakeEmployeeMutator) SetJoinedAt(value time.Time) bool {
+	m.recorder.Record(m.path+"SetJoinedAt", value)
+	return true
+}
+
+// SetProjects records the call, reporting a change.
+func (m *FakeEmployeeMutator) SetProjects(value []Project) bool {
+	m.recorder.Record(m.path+"SetProjects", value)
+	return true
+}
+
+// AppendProjects records the call.
+func (m *FakeEmployeeMutator) AppendProjects(value ...Project) {
+	m.recorder.Record(m.path+"AppendProjects", value)
+}
+
+// RemoveProjects records the call.
+func (m *FakeEmployeeMutator) RemoveProjects(index int) {
+	m.recorder.Record(m.path+"RemoveProjects", index)
+}
+
+// ProjectsAt returns a fake recording the calls to the methods of the mutator.
+func (m *FakeEmployeeMutator) ProjectsAt(index int) ProjectMutator {
+	return &FakeProjectMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("ProjectsAt(%+v).", index),
+	}
+}
+
+// SetAudit records the call, reporting a change.
+func (m *FakeEmployeeMutator) SetAudit(value *Audit) bool {
+	m.recorder.Record(m.path+"SetAudit", value)
+	return true
+}
+
+// Audit returns a fake recording the calls to the methods of the mutator.
+func (m *FakeEmployeeMutator) Audit() AuditMutator {
+	return &FakeAuditMutator{
+		recorder: m.recorder,
+		path:     m.path + "Audit().",
+	}
+}
+
+// SetCreatedBy records the call, reporting a change.
+func (m *FakeEmployeeMutator) SetCreatedBy(value string) bool {
+	m.recorder.Record(m.path+"SetCreatedBy", value)
+	return true
+}
+
+// SetRevision records the call, reporting a change.
+func (m *FakeEmployeeMutator) SetRevision(value int) bool {
+	m.recorder.Record(m.path+"SetRevision", value)
+	return true
+}
+
+// ProjectMutator is implemented by MutatorProject, and by FakeProjectMutator in tests.
+type ProjectMutator interface {
+	SetName(value string) bool
+	SetValue(value int) bool
+	SetStartedAt(value time.Time) bool
+	SetFinishedAt(value time.Time) bool
+	SetSeqID(value []byte) bool
+}
+
+var _ ProjectMutator = (*MutatorProject)(nil)
+
+// FakeProjectMutator implements ProjectMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeProjectMutator struct {
+	recorder *changes.Recorder
+	path     string
+}
+
+// NewFakeProjectMutator creates a FakeProjectMutator recording calls into recorder.
+func NewFakeProjectMutator(recorder *changes.Recorder) *FakeProjectMutator {
+	return &FakeProjectMutator{
+		recorder: recorder,
+	}
+}
+
+// SetName records the call, reporting a change.
+func (m *FakeProjectMutator) SetName(value string) bool {
+	m.recorder.Record(m.path+"SetName", value)
+	return true
+}
+
+// SetValue records the call, reporting a change.
+func (m *FakeProjectMutator) SetValue(value int) bool {
+	m.recorder.Record(m.path+"SetValue", value)
+	return true
+}
+
+// SetStartedAt records the call, reporting a change.
+func (m *FakeProjectMutator) SetStartedAt(value time.Time) bool {
+	m.recorder.Record(m.path+"SetStartedAt", value)
+	return true
+}
+
+// SetFinishedAt records the call, reporting a change.
+func (m *FakeProjectMutator) SetFinishedAt(value time.Time) bool {
+	m.recorder.Record(m.path+"SetFinishedAt", value)
+	return true
+}
+
+// SetSeqID records the call, reporting a change.
+func (m *FakeProjectMutator) SetSeqID(value []byte) bool {
+	m.recorder.Record(m.path+"SetSeqID", value)
+	return true
+}
+
+// AddressMutator is implemented by MutatorAddress, and by FakeAddressMutator in tests.
+type AddressMutator interface {
+	SetStreet(value string) bool
+	SetNumber(value int) bool
+	SetCity(value string) bool
+	SetZip(value int) bool
+	SetLocation(value *string) bool
+}
+
+var _ AddressMutator = (*MutatorAddress)(nil)
+
+// FakeAddressMutator implements AddressMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeAddressMutator struct {
+	recorder *changes.Recorder
+	path     string
+}
+
+// NewFakeAddressMutator creates a FakeAddressMutator recording calls into recorder.
+func NewFakeAddressMutator(recorder *changes.Recorder) *FakeAddressMutator {
+	return &FakeAddressMutator{
+		recorder: recorder,
+	}
+}
+
+// SetStreet records the call, reporting a change.
+func (m *FakeAddressMutator) SetStreet(value string) bool {
+	m.recorder.Record(m.path+"SetStreet", value)
+	return true
+}
 
+// SetNumber records the call, reporting a change.
+func (m *FakeAddressMutator) SetNumber(value int) bool {
+	m.recorder.Record(m.path+"SetNumber", value)
 	return true
 }
 
-// SetFinishedAt mutates the FinishedAt of the Project object
-func (m *MutatorProject) SetFinishedAt(value time.Time) bool {
-	if m.inner.FinishedAt == value {
-		return false
-	}
-
-	operation := changes.OperationUpdated
-	if reflect.ValueOf(m.inner.FinishedAt).IsZero() {
-		operation = changes.OperationSet
-	} else if reflect.ValueOf(value).IsZero() {
-		operation = changes.OperationCleared
-	}
+// SetCity records the call, reporting a change.
+func (m *FakeAddressMutator) SetCity(value string) bool {
+	m.recorder.Record(m.path+"SetCity", value)
+	return true
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "FinishedAt",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.FinishedAt),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.FinishedAt = value
+// SetZip records the call, reporting a change.
+func (m *FakeAddressMutator) SetZip(value int) bool {
+	m.recorder.Record(m.path+"SetZip", value)
+	return true
+}
 
+// SetLocation records the call, reporting a change.
+func (m *FakeAddressMutator) SetLocation(value *string) bool {
+	m.recorder.Record(m.path+"SetLocation", value)
 	return true
 }
 
-// SetSeqID mutates the SeqID of the Project object
-func (m *MutatorProject) SetSeqID(value []byte) bool {
-	if bytes.Equal(m.inner.SeqID, value) {
-		return false
-	}
+// VatMutator is implemented by MutatorVat, and by FakeVatMutator in tests.
+type VatMutator interface {
+	SetNumber(value string) bool
+	SetType(value string) bool
+}
 
-	operation := changes.OperationUpdated
-	if len(m.inner.SeqID) == 0 {
-		operation = changes.OperationSet
-	} else if len(value) == 0 {
-		operation = changes.OperationCleared
-	}
+var _ VatMutator = (*MutatorVat)(nil)
 
-	oldValue := base64.StdEncoding.EncodeToString(m.inner.SeqID)
-	newValue := base64.StdEncoding.EncodeToString(value)
+// FakeVatMutator implements VatMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeVatMutator struct {
+	recorder *changes.Recorder
+	path     string
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "SeqID",
-		Operation: operation,
-		OldValue:  oldValue,
-		NewValue:  newValue,
-	})
-	m.inner.SeqID = value
+// NewFakeVatMutator creates a FakeVatMutator recording calls into recorder.
+func NewFakeVatMutator(recorder *changes.Recorder) *FakeVatMutator {
+	return &FakeVatMutator{
+		recorder: recorder,
+	}
+}
 
+// SetNumber records the call, reporting a change.
+func (m *FakeVatMutator) SetNumber(value string) bool {
+	m.recorder.Record(m.path+"SetNumber", value)
 	return true
 }
 
-// SetProjects sets Projects of the Employee object
-func (m *MutatorEmployee) SetProjects(value []Project) bool {
+// SetType records the call, reporting a change.
+func (m *FakeVatMutator) SetType(value string) bool {
+	m.recorder.Record(m.path+"SetType", value)
+	return true
+}
 
-	if len(value) == 0 && len(m.inner.Projects) == 0 {
-		return false
-	}
+// BillingAccountMutator is implemented by MutatorBillingAccount, and by FakeBillingAccountMutator in tests.
+type BillingAccountMutator interface {
+	SetIBAN(value string) bool
+	SetHolder(value string) bool
+	SetLimits(value *billing.Limits) bool
+	Limits() BillingLimitsMutator
+	SetCurrency(value billing.Currency) bool
+	SetFunding(value billing.Funding) bool
+}
 
-	operation := changes.OperationSet
-	if len(value) == 0 {
-		operation = changes.OperationCleared
+var _ BillingAccountMutator = (*MutatorBillingAccount)(nil)
+
+// FakeBillingAccountMutator implements BillingAccountMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeBillingAccountMutator struct {
+	recorder *changes.Recorder
+	path     string
+}
+
+// NewFakeBillingAccountMutator creates a FakeBillingAccountMutator recording calls into recorder.
+func NewFakeBillingAccountMutator(recorder *changes.Recorder) *FakeBillingAccountMutator {
+	return &FakeBillingAccountMutator{
+		recorder: recorder,
 	}
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Projects",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Projects),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.Projects = value
+// SetIBAN records the call, reporting a change.
+func (m *FakeBillingAccountMutator) SetIBAN(value string) bool {
+	m.recorder.Record(m.path+"SetIBAN", value)
+	return true
+}
 
+// SetHolder records the call, reporting a change.
+func (m *FakeBillingAccountMutator) SetHolder(value string) bool {
+	m.recorder.Record(m.path+"SetHolder", value)
 	return true
 }
 
-// AppendProjects appends a Projects element of the Employee object.
-func (m *MutatorEmployee) AppendProjects(value ...Project) {
-	var appended any = value
-	if len(value) == 1 {
-		appended = value[0]
+// SetLimits records the call, reporting a change.
+func (m *FakeBillingAccountMutator) SetLimits(value *billing.Limits) bool {
+	m.recorder.Record(m.path+"SetLimits", value)
+	return true
+}
+
+// Limits returns a fake recording the calls to the methods of the mutator.
+func (m *FakeBillingAccountMutator) Limits() BillingLimitsMutator {
+	return &FakeBillingLimitsMutator{
+		recorder: m.recorder,
+		path:     m.path + "Limits().",
 	}
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Projects",
-		Operation: changes.OperationAdded,
-		NewValue:  fmt.Sprintf("%+v", appended),
-	})
-	m.inner.Projects = append(m.inner.Projects, value...)
+// SetCurrency records the call, reporting a change.
+func (m *FakeBillingAccountMutator) SetCurrency(value billing.Currency) bool {
+	m.recorder.Record(m.path+"SetCurrency", value)
+	return true
 }
 
-// RemoveProjects removes a Projects element of the Employee object.
-func (m *MutatorEmployee) RemoveProjects(index int) {
-	m.changes.Append(changes.Change{
-		FieldName: "Projects",
-		Operation: changes.OperationRemoved,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Projects[index]),
-	})
-	m.inner.Projects = append(m.inner.Projects[:index], m.inner.Projects[index+1:]...)
+// SetFunding records the call, reporting a change.
+func (m *FakeBillingAccountMutator) SetFunding(value billing.Funding) bool {
+	m.recorder.Record(m.path+"SetFunding", value)
+	return true
 }
 
-// ProjectsAt returns a mutator for Projects element at index of the Employee object.
-func (m *MutatorEmployee) ProjectsAt(index int) *MutatorProject {
-	object := &m.inner.Projects[index]
+// BillingLimitsMutator is implemented by MutatorBillingLimits, and by FakeBillingLimitsMutator in tests.
+type BillingLimitsMutator interface {
+	SetDaily(value int) bool
+	SetMonthly(value int) bool
+}
 
-	prefix := changes.NewPrefixWithKey(MutationPrefixEmployeesProjects, changes.IntoKey(object))
+var _ BillingLimitsMutator = (*MutatorBillingLimits)(nil)
 
-	return NewMutatorProject(
-		object,
-		changes.NewChainedLogger(prefix, m.changes),
-	)
+// FakeBillingLimitsMutator implements BillingLimitsMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeBillingLimitsMutator struct {
+	recorder *changes.Recorder
+	path     string
 }
 
+// NewFakeBillingLimitsMutator creates a FakeBillingLimitsMutator recording calls into recorder.
+func NewFakeBillingLimitsMutator(recorder *changes.Recorder) *FakeBillingLimitsMutator {
+	return &FakeBillingLimitsMutator{
+		recorder: recorder,
+	}
+}
 
-// SetEmployees sets Employees of the Acme object
-func (m *MutatorAcme) SetEmployees(value []*Employee) bool {
+// SetDaily records the call, reporting a change.
+func (m *FakeBillingLimitsMutator) SetDaily(value int) bool {
+	m.recorder.Record(m.path+"SetDaily", value)
+	return true
+}
 
-	if len(value) == 0 && len(m.inner.Employees) == 0 {
-		return false
-	}
+// SetMonthly records the call, reporting a change.
+func (m *FakeBillingLimitsMutator) SetMonthly(value int) bool {
+	m.recorder.Record(m.path+"SetMonthly", value)
+	return true
+}
 
-	operation := changes.OperationSet
-	if len(value) == 0 {
-		operation = changes.OperationCleared
-	}
+// TagMutator is implemented by MutatorTag, and by FakeTagMutator in tests.
+type TagMutator interface {
+	SetName(value string) bool
+	SetColor(value string) bool
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Employees",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Employees),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.Employees = value
+var _ TagMutator = (*MutatorTag)(nil)
 
-	return true
+// FakeTagMutator implements TagMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeTagMutator struct {
+	recorder *changes.Recorder
+	path     string
 }
 
-// AppendEmployees appends a Employees element of the Acme object.
-func (m *MutatorAcme) AppendEmployees(value ...*Employee) {
-	var appended any = value
-	if len(value) == 1 {
-		appended = value[0]
+// NewFakeTagMutator creates a FakeTagMutator recording calls into recorder.
+func NewFakeTagMutator(recorder *changes.Recorder) *FakeTagMutator {
+	return &FakeTagMutator{
+		recorder: recorder,
 	}
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Employees",
-		Operation: changes.OperationAdded,
-		NewValue:  fmt.Sprintf("%+v", appended),
-	})
-	m.inner.Employees = append(m.inner.Employees, value...)
+// SetName records the call, reporting a change.
+func (m *FakeTagMutator) SetName(value string) bool {
+	m.recorder.Record(m.path+"SetName", value)
+	return true
 }
 
-// RemoveEmployees removes a Employees element of the Acme object.
-func (m *MutatorAcme) RemoveEmployees(index int) {
-	m.changes.Append(changes.Change{
-		FieldName: "Employees",
-		Operation: changes.OperationRemoved,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Employees[index]),
-	})
-	m.inner.Employees = append(m.inner.Employees[:index], m.inner.Employees[index+1:]...)
+// SetColor records the call, reporting a change.
+func (m *FakeTagMutator) SetColor(value string) bool {
+	m.recorder.Record(m.path+"SetColor", value)
+	return true
 }
 
-// EmployeesAt returns a mutator for Employees element at index of the Acme object.
-func (m *MutatorAcme) EmployeesAt(index int) *MutatorEmployee {
-	object := m.inner.Employees[index]
+// PagePtrEmployeeMutator is implemented by MutatorPagePtrEmployee, and by FakePagePtrEmployeeMutator in tests.
+type PagePtrEmployeeMutator interface {
+	SetItems(value []*Employee) bool
+	AppendItems(value ...*Employee)
+	RemoveItems(index int)
+	ItemsAt(index int) EmployeeMutator
+	ItemsByPtr(ptr *Employee) EmployeeMutator
+	SetCursor(value string) bool
+	SetLast(value *Employee) bool
+	Last() EmployeeMutator
+	SetNext(value *Page[*Employee]) bool
+	Next() PagePtrEmployeeMutator
+}
 
-	prefix := changes.NewPrefixWithKey(MutationPrefixEmployees, changes.IntoKey(object))
+var _ PagePtrEmployeeMutator = (*MutatorPagePtrEmployee)(nil)
 
-	return NewMutatorEmployee(
-		object,
-		changes.NewChainedLogger(prefix, m.changes),
-	)
+// FakePagePtrEmployeeMutator implements PagePtrEmployeeMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakePagePtrEmployeeMutator struct {
+	recorder *changes.Recorder
+	path     string
 }
 
-// EmployeesByPtr returns a mutator for Employees element given by a pointer of type Acme.
-func (m *MutatorAcme) EmployeesByPtr(ptr *Employee) *MutatorEmployee {
-	for i, item := range m.inner.Employees {
-		if item == ptr {
-			return m.EmployeesAt(i)
-		}
+// NewFakePagePtrEmployeeMutator creates a FakePagePtrEmployeeMutator recording calls into recorder.
+func NewFakePagePtrEmployeeMutator(recorder *changes.Recorder) *FakePagePtrEmployeeMutator {
+	return &FakePagePtrEmployeeMutator{
+		recorder: recorder,
 	}
-	return nil
 }
 
-// SetStreet mutates the Street of the Address object
-func (m *MutatorAddress) SetStreet(value string) bool {
-	if m.inner.Street == value {
-		return false
-	}
-
-	operation := changes.OperationUpdated
-	if reflect.ValueOf(m.inner.Street).IsZero() {
-		operation = changes.OperationSet
-	} else if reflect.ValueOf(value).IsZero() {
-		operation = changes.OperationCleared
-	}
+// SetItems records the call, reporting a change.
+func (m *FakePagePtrEmployeeMutator) SetItems(value []*Employee) bool {
+	m.recorder.Record(m.path+"SetItems", value)
+	return true
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Street",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Street),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.Street = value
+// AppendItems records the call.
+func (m *FakePagePtrEmployeeMutator) AppendItems(value ...*Employee) {
+	m.recorder.Record(m.path+"AppendItems", value)
+}
 
-	return true
+// RemoveItems records the call.
+func (m *FakePagePtrEmployeeMutator) RemoveItems(index int) {
+	m.recorder.Record(m.path+"RemoveItems", index)
 }
 
-// SetNumber mutates the Number of the Address object
-func (m *MutatorAddress) SetNumber(value int) bool {
-	if m.inner.Number == value {
-		return false
+// ItemsAt returns a fake recording the calls to the methods of the mutator.
+func (m *FakePagePtrEmployeeMutator) ItemsAt(index int) EmployeeMutator {
+	return &FakeEmployeeMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("ItemsAt(%+v).", index),
 	}
+}
 
-	operation := changes.OperationUpdated
-	if reflect.ValueOf(m.inner.Number).IsZero() {
-		operation = changes.OperationSet
-	} else if reflect.ValueOf(value).IsZero() {
-		operation = changes.OperationCleared
+// ItemsByPtr returns a fake recording the calls to the methods of the mutator.
+func (m *FakePagePtrEmployeeMutator) ItemsByPtr(ptr *Employee) EmployeeMutator {
+	return &FakeEmployeeMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("ItemsByPtr(%+v).", ptr),
 	}
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Number",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Number),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.Number = value
-
+// SetCursor records the call, reporting a change.
+func (m *FakePagePtrEmployeeMutator) SetCursor(value string) bool {
+	m.recorder.Record(m.path+"SetCursor", value)
 	return true
 }
 
-// SetCity mutates the City of the Address object
-func (m *MutatorAddress) SetCity(value string) bool {
-	if m.inner.City == value {
-		return false
-	}
+// SetLast records the call, reporting a change.
+func (m *FakePagePtrEmployeeMutator) SetLast(value *Employee) bool {
+	m.recorder.Record(m.path+"SetLast", value)
+	return true
+}
 
-	operation := changes.OperationUpdated
-	if reflect.ValueOf(m.inner.City).IsZero() {
-		operation = changes.OperationSet
-	} else if reflect.ValueOf(value).IsZero() {
-		operation = changes.OperationCleared
+// Last returns a fake recording the calls to the methods of the mutator.
+func (m *FakePagePtrEmployeeMutator) Last() EmployeeMutator {
+	return &FakeEmployeeMutator{
+		recorder: m.recorder,
+		path:     m.path + "Last().",
 	}
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "City",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.City),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.City = value
-
+// SetNext records the call, reporting a change.
+func (m *FakePagePtrEmployeeMutator) SetNext(value *Page[*Employee]) bool {
+	m.recorder.Record(m.path+"SetNext", value)
 	return true
 }
 
-// SetZip mutates the Zip of the Address object
-func (m *MutatorAddress) SetZip(value int) bool {
-	if m.inner.Zip == value {
-		return false
+// Next returns a fake recording the calls to the methods of the mutator.
+func (m *FakePagePtrEmployeeMutator) Next() PagePtrEmployeeMutator {
+	return &FakePagePtrEmployeeMutator{
+		recorder: m.recorder,
+		path:     m.path + "Next().",
 	}
+}
 
-	operation := changes.OperationUpdated
-	if reflect.ValueOf(m.inner.Zip).IsZero() {
-		operation = changes.OperationSet
-	} else if reflect.ValueOf(value).IsZero() {
-		operation = changes.OperationCleared
-	}
+// CardMutator is implemented by MutatorCard, and by FakeCardMutator in tests.
+type CardMutator interface {
+	SetNumber(value string) bool
+	SetHolder(value string) bool
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Zip",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Zip),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.Zip = value
+var _ CardMutator = (*MutatorCard)(nil)
 
-	return true
+// FakeCardMutator implements CardMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeCardMutator struct {
+	recorder *changes.Recorder
+	path     string
 }
 
-// SetLocation sets Location of the Address object
-func (m *MutatorAddress) SetLocation(value *string) bool {
-
-	if value == nil && m.inner.Location == nil {
-		return false
+// NewFakeCardMutator creates a FakeCardMutator recording calls into recorder.
+func NewFakeCardMutator(recorder *changes.Recorder) *FakeCardMutator {
+	return &FakeCardMutator{
+		recorder: recorder,
 	}
+}
 
-	if value == m.inner.Location {
-		return false
-	}
+// SetNumber records the call, reporting a change.
+func (m *FakeCardMutator) SetNumber(value string) bool {
+	m.recorder.Record(m.path+"SetNumber", value)
+	return true
+}
+
+// SetHolder records the call, reporting a change.
+func (m *FakeCardMutator) SetHolder(value string) bool {
+	m.recorder.Record(m.path+"SetHolder", value)
+	return true
+}
 
-	_, isStringer := interface{}(value).(fmt.Stringer)
+// WireMutator is implemented by MutatorWire, and by FakeWireMutator in tests.
+type WireMutator interface {
+	SetIBAN(value string) bool
+}
 
-	operation := changes.OperationCleared
-	valueStr := fmt.Sprintf("%+v", value)
-	oldValueStr := fmt.Sprintf("%+v", m.inner.Location)
+var _ WireMutator = (*MutatorWire)(nil)
 
-	if value != nil {
-		operation = changes.OperationSet
-		if !isStringer {
-			valueStr = fmt.Sprintf("%+v", *value)
-		}
-	}
+// FakeWireMutator implements WireMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeWireMutator struct {
+	recorder *changes.Recorder
+	path     string
+}
 
-	if m.inner.Location != nil {
-		if !isStringer {
-			oldValueStr = fmt.Sprintf("%+v", *m.inner.Location)
-		}
+// NewFakeWireMutator creates a FakeWireMutator recording calls into recorder.
+func NewFakeWireMutator(recorder *changes.Recorder) *FakeWireMutator {
+	return &FakeWireMutator{
+		recorder: recorder,
 	}
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Location",
-		Operation: operation,
-		OldValue:  oldValueStr,
-		NewValue:  valueStr,
-	})
-	m.inner.Location = value
-
+// SetIBAN records the call, reporting a change.
+func (m *FakeWireMutator) SetIBAN(value string) bool {
+	m.recorder.Record(m.path+"SetIBAN", value)
 	return true
 }
 
-// SetAddress sets Address of the Acme object
-func (m *MutatorAcme) SetAddress(value *Address) bool {
+// M2MetricsMutator is implemented by MutatorM2Metrics, and by FakeM2MetricsMutator in tests.
+type M2MetricsMutator interface {
+	SetVisits(value int) bool
+}
 
-	if value == nil && m.inner.Address == nil {
-		return false
-	}
+var _ M2MetricsMutator = (*MutatorM2Metrics)(nil)
 
-	if value == m.inner.Address {
-		return false
+// FakeM2MetricsMutator implements M2MetricsMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeM2MetricsMutator struct {
+	recorder *changes.Recorder
+	path     string
+}
+
+// NewFakeM2MetricsMutator creates a FakeM2MetricsMutator recording calls into recorder.
+func NewFakeM2MetricsMutator(recorder *changes.Recorder) *FakeM2MetricsMutator {
+	return &FakeM2MetricsMutator{
+		recorder: recorder,
 	}
+}
 
-	_, isStringer := interface{}(value).(fmt.Stringer)
+// SetVisits records the call, reporting a change.
+func (m *FakeM2MetricsMutator) SetVisits(value int) bool {
+	m.recorder.Record(m.path+"SetVisits", value)
+	return true
+}
 
-	operation := changes.OperationCleared
-	valueStr := fmt.Sprintf("%+v", value)
-	oldValueStr := fmt.Sprintf("%+v", m.inner.Address)
+// TeamMutator is implemented by MutatorTeam, and by FakeTeamMutator in tests.
+type TeamMutator interface {
+	SetName(value string) bool
+	SetMembers(value []*Employee) bool
+	AppendMembers(value ...*Employee)
+	RemoveMembers(index int)
+	MembersAt(index int) EmployeeMutator
+	MembersByPtr(ptr *Employee) EmployeeMutator
+	SetSpinoff(value *Department) bool
+	Spinoff() DepartmentMutator
+}
 
-	if value != nil {
-		operation = changes.OperationSet
-		if !isStringer {
-			valueStr = fmt.Sprintf("%+v", *value)
-		}
-	}
+var _ TeamMutator = (*MutatorTeam)(nil)
 
-	if m.inner.Address != nil {
-		if !isStringer {
-			oldValueStr = fmt.Sprintf("%+v", *m.inner.Address)
-		}
-	}
+// FakeTeamMutator implements TeamMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeTeamMutator struct {
+	recorder *changes.Recorder
+	path     string
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Address",
-		Operation: operation,
-		OldValue:  oldValueStr,
-		NewValue:  valueStr,
-	})
-	m.inner.Address = value
+// NewFakeTeamMutator creates a FakeTeamMutator recording calls into recorder.
+func NewFakeTeamMutator(recorder *changes.Recorder) *FakeTeamMutator {
+	return &FakeTeamMutator{
+		recorder: recorder,
+	}
+}
 
+// SetName records the call, reporting a change.
+func (m *FakeTeamMutator) SetName(value string) bool {
+	m.recorder.Record(m.path+"SetName", value)
 	return true
 }
 
-// Address returns a mutator for Address of the Acme object.
-// If the field is nil, it will be initialized to a new Address object.
-func (m *MutatorAcme) Address() *MutatorAddress {
-
-	if m.inner.Address == nil {
-		m.inner.Address = &Address{}
-	}
+// SetMembers records the call, reporting a change.
+func (m *FakeTeamMutator) SetMembers(value []*Employee) bool {
+	m.recorder.Record(m.path+"SetMembers", value)
+	return true
+}
 
-	prefix := changes.NewPrefix(MutationPrefixAddress)
+// AppendMembers records the call.
+func (m *FakeTeamMutator) AppendMembers(value ...*Employee) {
+	m.recorder.Record(m.path+"AppendMembers", value)
+}
 
-	return NewMutatorAddress(m.inner.Address, changes.NewChainedLogger(prefix, m.changes))
+// RemoveMembers records the call.
+func (m *FakeTeamMutator) RemoveMembers(index int) {
+	m.recorder.Record(m.path+"RemoveMembers", index)
 }
 
-// SetNumber mutates the Number of the Vat object
-func (m *MutatorVat) SetNumber(value string) bool {
-	if m.inner.Number == value {
-		return false
+// MembersAt returns a fake recording the calls to the methods of the mutator.
+func (m *FakeTeamMutator) MembersAt(index int) EmployeeMutator {
+	return &FakeEmployeeMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("MembersAt(%+v).", index),
 	}
+}
 
-	operation := changes.OperationUpdated
-	if reflect.ValueOf(m.inner.Number).IsZero() {
-		operation = changes.OperationSet
-	} else if reflect.ValueOf(value).IsZero() {
-		operation = changes.OperationCleared
+// MembersByPtr returns a fake recording the calls to the methods of the mutator.
+func (m *FakeTeamMutator) MembersByPtr(ptr *Employee) EmployeeMutator {
+	return &FakeEmployeeMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("MembersByPtr(%+v).", ptr),
 	}
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Number",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Number),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.Number = value
-
+// SetSpinoff records the call, reporting a change.
+func (m *FakeTeamMutator) SetSpinoff(value *Department) bool {
+	m.recorder.Record(m.path+"SetSpinoff", value)
 	return true
 }
 
-// SetType mutates the Type of the Vat object
-func (m *MutatorVat) SetType(value string) bool {
-	if m.inner.Type == value {
-		return false
+// Spinoff returns a fake recording the calls to the methods of the mutator.
+func (m *FakeTeamMutator) Spinoff() DepartmentMutator {
+	return &FakeDepartmentMutator{
+		recorder: m.recorder,
+		path:     m.path + "Spinoff().",
 	}
+}
 
-	operation := changes.OperationUpdated
-	if reflect.ValueOf(m.inner.Type).IsZero() {
-		operation = changes.OperationSet
-	} else if reflect.ValueOf(value).IsZero() {
-		operation = changes.OperationCleared
-	}
+// ItemMutator is implemented by MutatorItem, and by FakeItemMutator in tests.
+type ItemMutator interface {
+	SetSKU(value string) bool
+	SetQuantity(value int) bool
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Type",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Type),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.Type = value
+var _ ItemMutator = (*MutatorItem)(nil)
 
-	return true
+// FakeItemMutator implements ItemMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeItemMutator struct {
+	recorder *changes.Recorder
+	path     string
 }
 
-// SetVat sets Vat of the Acme object
-func (m *MutatorAcme) SetVat(value *Vat) bool {
+// NewFakeItemMutator creates a FakeItemMutator recording calls into recorder.
+func NewFakeItemMutator(recorder *changes.Recorder) *FakeItemMutator {
+	return &FakeItemMutator{
+		recorder: recorder,
+	}
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Vat",
-		Operation: changes.OperationSet,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Vat),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.Vat = *value
+// SetSKU records the call, reporting a change.
+func (m *FakeItemMutator) SetSKU(value string) bool {
+	m.recorder.Record(m.path+"SetSKU", value)
+	return true
+}
 
+// SetQuantity records the call, reporting a change.
+func (m *FakeItemMutator) SetQuantity(value int) bool {
+	m.recorder.Record(m.path+"SetQuantity", value)
 	return true
 }
 
-// Vat returns a mutator for Vat of the Acme object.
-func (m *MutatorAcme) Vat() *MutatorVat {
-	prefix := changes.NewPrefix(MutationPrefixVat)
-	return NewMutatorVat(&m.inner.Vat, changes.NewChainedLogger(prefix, m.changes))
+// OrderLineMutator is implemented by MutatorOrderLine, and by FakeOrderLineMutator in tests.
+type OrderLineMutator interface {
+	SetItems(value []*Item) bool
+	AppendItems(value ...*Item)
+	RemoveItems(index int)
+	ItemsAt(index int) ItemMutator
+	ItemsByPtr(ptr *Item) ItemMutator
 }
 
-// SetNicknames sets Nicknames of the Acme object
-func (m *MutatorAcme) SetNicknames(value map[string]*Employee) bool {
+var _ OrderLineMutator = (*MutatorOrderLine)(nil)
 
-	if len(value) == 0 && len(m.inner.Nicknames) == 0 {
-		return false
-	}
+// FakeOrderLineMutator implements OrderLineMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeOrderLineMutator struct {
+	recorder *changes.Recorder
+	path     string
+}
 
-	operation := changes.OperationSet
-	if len(value) == 0 {
-		operation = changes.OperationCleared
+// NewFakeOrderLineMutator creates a FakeOrderLineMutator recording calls into recorder.
+func NewFakeOrderLineMutator(recorder *changes.Recorder) *FakeOrderLineMutator {
+	return &FakeOrderLineMutator{
+		recorder: recorder,
 	}
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Nicknames",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Nicknames),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.Nicknames = value
-
+// SetItems records the call, reporting a change.
+func (m *FakeOrderLineMutator) SetItems(value []*Item) bool {
+	m.recorder.Record(m.path+"SetItems", value)
 	return true
 }
 
-// InsertNicknames inserts a Nicknames map element of the Acme object.
-func (m *MutatorAcme) InsertNicknames(
-	key string,
-	value *Employee,
-) bool {
-	currentValue, exists := m.inner.Nicknames[key]
-	if exists && currentValue == value {
-		return false
-	}
+// AppendItems records the call.
+func (m *FakeOrderLineMutator) AppendItems(value ...*Item) {
+	m.recorder.Record(m.path+"AppendItems", value)
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Nicknames",
-		Operation: changes.OperationAdded,
-		Key:       changes.IntoKey(key),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
+// RemoveItems records the call.
+func (m *FakeOrderLineMutator) RemoveItems(index int) {
+	m.recorder.Record(m.path+"RemoveItems", index)
+}
+
+// ItemsAt returns a fake recording the calls to the methods of the mutator.
+func (m *FakeOrderLineMutator) ItemsAt(index int) ItemMutator {
+	return &FakeItemMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("ItemsAt(%+v).", index),
+	}
+}
 
-	if m.inner.Nicknames == nil {
-		m.inner.Nicknames = make(map[string]*Employee)
+// ItemsByPtr returns a fake recording the calls to the methods of the mutator.
+func (m *FakeOrderLineMutator) ItemsByPtr(ptr *Item) ItemMutator {
+	return &FakeItemMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("ItemsByPtr(%+v).", ptr),
 	}
+}
+
+// SliceTagMutator is implemented by MutatorSliceTag, and by FakeSliceTagMutator in tests.
+type SliceTagMutator interface {
+	Set(value []Tag) bool
+	Append(value ...Tag)
+	Remove(index int)
+	At(index int) TagMutator
+}
 
-	m.inner.Nicknames[key] = value
+var _ SliceTagMutator = (*MutatorSliceTag)(nil)
 
-	return true
+// FakeSliceTagMutator implements SliceTagMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeSliceTagMutator struct {
+	recorder *changes.Recorder
+	path     string
 }
 
-// RemoveNicknames removes a Nicknames map element of the Acme object.
-func (m *MutatorAcme) RemoveNicknames(key string) bool {
-	_, exists := m.inner.Nicknames[key]
-	if !exists {
-		return false
+// NewFakeSliceTagMutator creates a FakeSliceTagMutator recording calls into recorder.
+func NewFakeSliceTagMutator(recorder *changes.Recorder) *FakeSliceTagMutator {
+	return &FakeSliceTagMutator{
+		recorder: recorder,
 	}
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Nicknames",
-		Operation: changes.OperationRemoved,
-		Key:       changes.IntoKey(key),
-		OldValue:  fmt.Sprintf("%+v", m.inner.Nicknames[key]),
-	})
-	delete(m.inner.Nicknames, key)
-
+// Set records the call, reporting a change.
+func (m *FakeSliceTagMutator) Set(value []Tag) bool {
+	m.recorder.Record(m.path+"Set", value)
 	return true
 }
 
-// NicknamesWithKey returns a mutator for Nicknames map element Acme object with given key.
-func (m *MutatorAcme) NicknamesWithKey(key string) *MutatorEmployee {
-	object := m.inner.Nicknames[key]
+// Append records the call.
+func (m *FakeSliceTagMutator) Append(value ...Tag) {
+	m.recorder.Record(m.path+"Append", value)
+}
 
-	prefix := changes.NewPrefixWithKey(MutationPrefixNicknames, changes.IntoKey(object))
+// Remove records the call.
+func (m *FakeSliceTagMutator) Remove(index int) {
+	m.recorder.Record(m.path+"Remove", index)
+}
 
-	return NewMutatorEmployee(
-		object,
-		changes.NewChainedLogger(prefix, m.changes),
-	)
+// At returns a fake recording the calls to the methods of the mutator.
+func (m *FakeSliceTagMutator) At(index int) TagMutator {
+	return &FakeTagMutator{
+		recorder: m.recorder,
+		path:     m.path + fmt.Sprintf("At(%+v).", index),
+	}
 }
 
-// SetEquity sets Equity of the Acme object
-func (m *MutatorAcme) SetEquity(value map[*Employee]int) bool {
+// SliceStringMutator is implemented by MutatorSliceString, and by FakeSliceStringMutator in tests.
+type SliceStringMutator interface {
+	Set(value []string) bool
+	Append(value ...string)
+	Remove(index int)
+}
 
-	if len(value) == 0 && len(m.inner.Equity) == 0 {
-		return false
-	}
+var _ SliceStringMutator = (*MutatorSliceString)(nil)
 
-	operation := changes.OperationSet
-	if len(value) == 0 {
-		operation = changes.OperationCleared
-	}
+// FakeSliceStringMutator implements SliceStringMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeSliceStringMutator struct {
+	recorder *changes.Recorder
+	path     string
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Equity",
-		Operation: operation,
-		OldValue:  fmt.Sprintf("%+v", m.inner.Equity),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
-	m.inner.Equity = value
+// NewFakeSliceStringMutator creates a FakeSliceStringMutator recording calls into recorder.
+func NewFakeSliceStringMutator(recorder *changes.Recorder) *FakeSliceStringMutator {
+	return &FakeSliceStringMutator{
+		recorder: recorder,
+	}
+}
 
+// Set records the call, reporting a change.
+func (m *FakeSliceStringMutator) Set(value []string) bool {
+	m.recorder.Record(m.path+"Set", value)
 	return true
 }
 
-// InsertEquity inserts a Equity map element of the Acme object.
-func (m *MutatorAcme) InsertEquity(
-	key *Employee,
-	value int,
-) bool {
-	currentValue, exists := m.inner.Equity[key]
-	if exists && currentValue == value {
-		return false
-	}
+// Append records the call.
+func (m *FakeSliceStringMutator) Append(value ...string) {
+	m.recorder.Record(m.path+"Append", value)
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Equity",
-		Operation: changes.OperationAdded,
-		Key:       changes.IntoKey(key),
-		NewValue:  fmt.Sprintf("%+v", value),
-	})
+// Remove records the call.
+func (m *FakeSliceStringMutator) Remove(index int) {
+	m.recorder.Record(m.path+"Remove", index)
+}
 
-	if m.inner.Equity == nil {
-		m.inner.Equity = make(map[*Employee]int)
-	}
+// MapIntStringMutator is implemented by MutatorMapIntString, and by FakeMapIntStringMutator in tests.
+type MapIntStringMutator interface {
+	Set(value map[int]string) bool
+	Insert(key int, value string) bool
+	Remove(key int) bool
+}
 
-	m.inner.Equity[key] = value
+var _ MapIntStringMutator = (*MutatorMapIntString)(nil)
 
-	return true
+// FakeMapIntStringMutator implements MapIntStringMutator by recording the calls to its methods,
+// and to the methods of the mutators it returns, instead of mutating an object.
+// Its setters always report a change.
+type FakeMapIntStringMutator struct {
+	recorder *changes.Recorder
+	path     string
 }
 
-// RemoveEquity removes a Equity map element of the Acme object.
-func (m *MutatorAcme) RemoveEquity(key *Employee) bool {
-	_, exists := m.inner.Equity[key]
-	if !exists {
-		return false
+// NewFakeMapIntStringMutator creates a FakeMapIntStringMutator recording calls into recorder.
+func NewFakeMapIntStringMutator(recorder *changes.Recorder) *FakeMapIntStringMutator {
+	return &FakeMapIntStringMutator{
+		recorder: recorder,
 	}
+}
 
-	m.changes.Append(changes.Change{
-		FieldName: "Equity",
-		Operation: changes.OperationRemoved,
-		Key:       changes.IntoKey(key),
-		OldValue:  fmt.Sprintf("%+v", m.inner.Equity[key]),
-	})
-	delete(m.inner.Equity, key)
+// Set records the call, reporting a change.
+func (m *FakeMapIntStringMutator) Set(value map[int]string) bool {
+	m.recorder.Record(m.path+"Set", value)
+	return true
+}
+
+// Insert records the call, reporting a change.
+func (m *FakeMapIntStringMutator) Insert(key int, value string) bool {
+	m.recorder.Record(m.path+"Insert", key, value)
+	return true
+}
 
+// Remove records the call, reporting a change.
+func (m *FakeMapIntStringMutator) Remove(key int) bool {
+	m.recorder.Record(m.path+"Remove", key)
 	return true
 }
@@ -0,0 +1,44 @@
+#!/bin/sh
+# outdated.sh checks that -check fails on out of date copies of a generated
+# file, printing the same hunks as diff -u, see the Makefile.
+set -eu
+
+dir=$(mktemp -d)
+trap 'rm -rf "$dir"' EXIT
+
+gomutate() {
+	go run . -type Account "$@" ./testdata/billing/billing.go
+}
+
+gomutate -w "$dir/generated.go"
+
+# empty file
+: >"$dir/empty.go"
+
+# line removed at the start of the file
+sed 1d "$dir/generated.go" >"$dir/start.go"
+
+# line changed in the middle of the file, and line added at its end
+sed 's/^type MutatorAccount struct {$/type MutatorAccount struct { \/\/ stale/' "$dir/generated.go" >"$dir/middle.go"
+cp "$dir/generated.go" "$dir/end.go"
+echo "// stale" >>"$dir/end.go"
+
+# newline missing at the end of the file
+printf '%s' "$(cat "$dir/generated.go")" >"$dir/newline.go"
+
+for name in empty start middle end newline; do
+	file="$dir/$name.go"
+
+	status=0
+	gomutate -check -w "$file" >"$dir/$name.diff" 2>/dev/null || status=$?
+	if [ "$status" -ne 1 ]; then
+		echo "$name: -check exited with status $status, expected 1" >&2
+		exit 1
+	fi
+
+	diff -u "$file" "$dir/generated.go" | tail -n +3 >"$dir/$name.expected"
+	tail -n +3 "$dir/$name.diff" | diff -u "$dir/$name.expected" - || {
+		echo "$name: unexpected hunks" >&2
+		exit 1
+	}
+done
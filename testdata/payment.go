@@ -0,0 +1,35 @@
+package main
+
+// PaymentMethod is implemented by *Card and *Wire, which are mutated through
+// PaymentAsCard and PaymentAsWire, and by *voucher, which is unexported and
+// gets no mutator without -unexported, but may still be mutated through
+// reflection.
+type PaymentMethod interface {
+	Kind() string
+}
+
+type Card struct {
+	Number string
+	Holder string
+}
+
+func (c *Card) Kind() string {
+	return "card"
+}
+
+type Wire struct {
+	IBAN string
+}
+
+func (w *Wire) Kind() string {
+	return "wire"
+}
+
+type voucher struct {
+	code   string
+	Amount int
+}
+
+func (v *voucher) Kind() string {
+	return "voucher"
+}
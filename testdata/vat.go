@@ -0,0 +1,7 @@
+package main
+
+// Vat is declared apart from acme.go, whose types reference it.
+type Vat struct {
+	Number string
+	Type   string
+}